@@ -18,6 +18,8 @@ type ClusterCatalog struct {
 	TLSDirectory              string `yaml:"tls_directory"`
 	ServicesCIDR              string `yaml:"kubernetes_services_cidr"`
 	PodCIDR                   string `yaml:"kubernetes_pods_cidr"`
+	NodeCIDRMaskSize          int    `yaml:"kubernetes_node_cidr_mask_size"`
+	ClusterDNSDomain          string `yaml:"kubernetes_cluster_dns_domain"`
 	DNSServiceIP              string `yaml:"kubernetes_dns_service_ip"`
 	EnableModifyHosts         bool   `yaml:"modify_hosts_file"`
 	EnablePackageInstallation bool   `yaml:"allow_package_installation"`
@@ -25,6 +27,16 @@ type ClusterCatalog struct {
 	KuberangPath              string `yaml:"kuberang_path"`
 	LoadBalancer              string `yaml:"kubernetes_load_balancer"`
 	LoadBalancerPort          string `yaml:"kubernetes_load_balancer_port"`
+	KubeProxyMode             string `yaml:"kube_proxy_mode"`
+	AllowWorkloadsOnMasters   bool   `yaml:"allow_workloads_on_masters"`
+	WorkerStartupTaint        bool   `yaml:"worker_startup_taint"`
+
+	EtcdAutoCompactionRetentionHours int    `yaml:"etcd_auto_compaction_retention_hours"`
+	EtcdDefragSchedule               string `yaml:"etcd_defrag_schedule"`
+	EtcdTimeoutSeconds               int    `yaml:"etcd_timeout_seconds"`
+	ControlPlaneTimeoutSeconds       int    `yaml:"control_plane_timeout_seconds"`
+	CNITimeoutSeconds                int    `yaml:"cni_timeout_seconds"`
+	SystemPodsTimeoutSeconds         int    `yaml:"system_pods_timeout_seconds"`
 
 	APIServerOptions             map[string]string `yaml:"kubernetes_api_server_option_overrides"`
 	KubeControllerManagerOptions map[string]string `yaml:"kube_controller_manager_option_overrides"`
@@ -51,12 +63,17 @@ type ClusterCatalog struct {
 
 	EnableConfigureIngress bool `yaml:"configure_ingress"`
 
+	IngressDefaultCertificate string `yaml:"ingress_default_certificate_local"`
+	IngressDefaultKey         string `yaml:"ingress_default_key_local"`
+
 	KismaticPreflightCheckerLinux string `yaml:"kismatic_preflight_checker"`
 
 	NewNode string `yaml:"new_node"`
 
 	NFSVolumes []NFSVolume `yaml:"nfs_volumes"`
 
+	ResourceDefaults []ResourceDefaults `yaml:"resource_defaults"`
+
 	EnableGluster bool `yaml:"configure_storage"`
 
 	// volume add vars
@@ -90,6 +107,7 @@ type ClusterCatalog struct {
 			OptsList             []string             `yaml:"opts_list"`
 			DirectLVMBlockDevice DirectLVMBlockDevice `yaml:"direct_lvm_block_device"`
 		}
+		RegistryMirrors []string `yaml:"registry_mirrors"`
 	}
 
 	LocalKubeconfigDirectory string `yaml:"local_kubeconfig_directory"`
@@ -97,11 +115,22 @@ type ClusterCatalog struct {
 	CloudProvider string `yaml:"cloud_provider"`
 	CloudConfig   string `yaml:"cloud_config_local"`
 
+	OIDC struct {
+		IssuerURL     string `yaml:"issuer_url"`
+		ClientID      string `yaml:"client_id"`
+		UsernameClaim string `yaml:"username_claim"`
+		GroupsClaim   string `yaml:"groups_claim"`
+		CAFile        string `yaml:"ca_file_local"`
+	}
+
 	DNS struct {
 		Enabled  bool
 		Provider string
 		Options  struct {
-			Replicas int
+			Replicas  int
+			CacheTTL  int      `yaml:"cache_ttl"`
+			Upstreams []string `yaml:"upstreams"`
+			Autopath  bool     `yaml:"autopath"`
 		}
 	}
 
@@ -143,6 +172,10 @@ type ClusterCatalog struct {
 
 	MetricsServer struct {
 		Enabled bool
+		Options struct {
+			KubeletInsecureTLS bool   `yaml:"kubelet_insecure_tls"`
+			MetricResolution   string `yaml:"metric_resolution"`
+		}
 	}
 
 	Dashboard struct {
@@ -185,6 +218,13 @@ type NFSVolume struct {
 	Path string
 }
 
+type ResourceDefaults struct {
+	Namespace       string
+	DefaultRequests map[string]string `yaml:"default_requests"`
+	DefaultLimits   map[string]string `yaml:"default_limits"`
+	Quota           map[string]string
+}
+
 type AdditionalFile struct {
 	Source      string
 	Destination string
@@ -113,7 +113,7 @@ func (fp *fakePKI) GenerateClusterCerts(p *install.Plan, ca *tls.CA, users []str
 	return fp.err
 }
 
-func (fp *fakePKI) GenerateCertificate(name string, validityPeriod string, commonName string, subjectAlternateNames []string, organizations []string, ca *tls.CA, overwrite bool) (bool, error) {
+func (fp *fakePKI) GenerateCertificate(name string, validityPeriod string, commonName string, subjectAlternateNames []string, organizations []string, ca *tls.CA, certs install.CertsConfig, overwrite bool) (bool, error) {
 	fp.called = true
 	return false, fp.err
 }
@@ -28,6 +28,7 @@ func NewCmdInstall(in io.Reader, out io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdApply(out, opts))
 	cmd.AddCommand(NewCmdAddNode(out, opts))
 	cmd.AddCommand(NewCmdStep(out, opts))
+	cmd.AddCommand(NewCmdKubeadmConfig(out, opts))
 
 	// PersistentFlags
 	addPlanFileFlag(cmd.PersistentFlags(), &opts.planFilename)
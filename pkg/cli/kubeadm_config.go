@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apprenda/kismatic/pkg/install"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdKubeadmConfig returns the command that prints the plan as a kubeadm configuration
+func NewCmdKubeadmConfig(out io.Writer, opts *installOpts) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kubeadm-config",
+		Short: "print the plan as a kubeadm configuration file",
+		Long: `Print the plan as a kubeadm MasterConfiguration (kubeadm.k8s.io/v1alpha1).
+
+The configuration includes the Kubernetes version, the API server endpoint,
+the etcd endpoints and client certificates, the networking CIDRs, the API
+server certificate SANs, the API server OIDC, admission plugin and TLS
+options, the component option overrides, the cloud provider, the image
+registry and the kube-proxy mode.
+
+Plan fields that have no kubeadm equivalent are not included: add-ons,
+docker configuration, package installation settings, kubelet option
+overrides, node labels and taints, additional files, NFS and storage.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("Unexpected args: %v", args)
+			}
+			planner := &install.FilePlanner{File: opts.planFilename}
+			return doKubeadmConfig(out, planner, opts.planFilename)
+		},
+	}
+	return cmd
+}
+
+func doKubeadmConfig(out io.Writer, planner install.Planner, planFile string) error {
+	if !planner.PlanExists() {
+		return planFileNotFoundErr{filename: planFile}
+	}
+	plan, err := planner.Read()
+	if err != nil {
+		return fmt.Errorf("error reading plan file: %v", err)
+	}
+	config, err := install.KubeadmConfig(*plan)
+	if err != nil {
+		return fmt.Errorf("error generating kubeadm configuration: %v", err)
+	}
+	_, err = out.Write(config)
+	return err
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/apprenda/kismatic/pkg/install"
 	"github.com/apprenda/kismatic/pkg/util"
@@ -45,6 +46,15 @@ func doPlan(in io.Reader, out io.Writer, planner install.Planner, planFile strin
 		return fmt.Errorf("The number of master nodes must be greater than zero")
 	}
 
+	ans, err := util.PromptForString(in, out, "Run etcd on the master nodes?", "N", []string{"N", "y"})
+	if err != nil {
+		return fmt.Errorf("Error reading etcd topology: %v", err)
+	}
+	stackedEtcd := strings.ToLower(ans) == "y"
+	if stackedEtcd && etcdNodes != masterNodes {
+		return fmt.Errorf("The number of etcd nodes must equal the number of master nodes when etcd runs on the master nodes")
+	}
+
 	workerNodes, err := util.PromptForInt(in, out, "Number of worker nodes", 3)
 	if err != nil {
 		return fmt.Errorf("Error reading number of worker nodes: %v", err)
@@ -81,6 +91,9 @@ func doPlan(in io.Reader, out io.Writer, planner install.Planner, planFile strin
 	fmt.Fprintf(out, "Generating installation plan file template with: \n")
 	fmt.Fprintf(out, "- %d etcd nodes\n", etcdNodes)
 	fmt.Fprintf(out, "- %d master nodes\n", masterNodes)
+	if stackedEtcd {
+		fmt.Fprintf(out, "- etcd running on the master nodes\n")
+	}
 	fmt.Fprintf(out, "- %d worker nodes\n", workerNodes)
 	fmt.Fprintf(out, "- %d ingress nodes\n", ingressNodes)
 	fmt.Fprintf(out, "- %d storage nodes\n", storageNodes)
@@ -90,6 +103,7 @@ func doPlan(in io.Reader, out io.Writer, planner install.Planner, planFile strin
 	planTemplate := install.PlanTemplateOptions{
 		EtcdNodes:       etcdNodes,
 		MasterNodes:     masterNodes,
+		StackedEtcd:     stackedEtcd,
 		WorkerNodes:     workerNodes,
 		IngressNodes:    ingressNodes,
 		StorageNodes:    storageNodes,
@@ -2,23 +2,24 @@ package cli
 
 import (
 	"bytes"
-	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func TestPlanCmdPlanNotFound(t *testing.T) {
 	tests := []struct {
-		in              io.Reader
+		in              string
 		shouldError     bool
 		expectedEtcd    int
 		expectedMaster  int
+		expectedStacked bool
 		expectedWorker  int
 		expectedIngress int
 	}{
 		{
 			// User accepts default node counts
-			in:              strings.NewReader("\n\n\n\n"),
+			in:              "\n\n\n\n\n",
 			expectedEtcd:    3,
 			expectedMaster:  2,
 			expectedWorker:  3,
@@ -26,7 +27,7 @@ func TestPlanCmdPlanNotFound(t *testing.T) {
 		},
 		{
 			// User enters node counts
-			in:              strings.NewReader("8\n\n\n\n"),
+			in:              "8\n\n\n\n\n",
 			expectedEtcd:    8,
 			expectedMaster:  2,
 			expectedWorker:  3,
@@ -34,7 +35,7 @@ func TestPlanCmdPlanNotFound(t *testing.T) {
 		},
 		{
 			// User enters node counts
-			in:              strings.NewReader("8\n\n\n3\n"),
+			in:              "8\n\nN\n\n3\n",
 			expectedEtcd:    8,
 			expectedMaster:  2,
 			expectedWorker:  3,
@@ -42,61 +43,98 @@ func TestPlanCmdPlanNotFound(t *testing.T) {
 		},
 		{
 			// User enters node counts
-			in:              strings.NewReader("8\n\n\n0\n"),
+			in:              "8\n\n\n\n0\n",
 			expectedEtcd:    8,
 			expectedMaster:  2,
 			expectedWorker:  3,
 			expectedIngress: 0,
 		},
+		{
+			// User runs etcd on the master nodes
+			in:              "3\n3\ny\n5\n\n",
+			expectedEtcd:    3,
+			expectedMaster:  3,
+			expectedStacked: true,
+			expectedWorker:  5,
+			expectedIngress: 2,
+		},
+		{
+			// User runs etcd on separate nodes, with a different count
+			in:              "5\n3\nN\n\n\n",
+			expectedEtcd:    5,
+			expectedMaster:  3,
+			expectedWorker:  3,
+			expectedIngress: 2,
+		},
+		{
+			// User runs etcd on the master nodes, with a different count
+			in:          "3\n2\ny\n\n\n",
+			shouldError: true,
+		},
+		{
+			// User enters invalid etcd topology
+			in:          "3\n3\nmaybe\n\n\n",
+			shouldError: true,
+		},
 		{
 			// User enters invalid numeric input
-			in:          strings.NewReader("0\n1\n1\n0\n"),
+			in:          "0\n1\nN\n1\n0\n",
 			shouldError: true,
 		},
 		{
 			// User enters invalid numeric input
-			in:          strings.NewReader("3\n2\n3\n-1\n"),
+			in:          "3\n2\nN\n3\n-1\n",
 			shouldError: true,
 		},
 		{
 			// User enters invalid numeric input
-			in:          strings.NewReader("3\n2\n3\nfoo\n"),
+			in:          "3\n2\nN\n3\nfoo\n",
 			shouldError: true,
 		},
 		{
 			// User enters invalid input
-			in:          strings.NewReader("badInput\n\n"),
+			in:          "badInput\n\n",
 			shouldError: true,
 		},
 		{
 			// User enters invalid input
-			in:          strings.NewReader("badInput\nother\nfail\n\n"),
+			in:          "badInput\nother\nfail\n\n",
 			shouldError: true,
 		},
 	}
-	for _, test := range tests {
+	for i, test := range tests {
 		out := &bytes.Buffer{}
 		fp := &fakePlanner{
 			exists: true,
 		}
 
-		err := doPlan(test.in, out, fp, "")
+		// read one byte at a time, so that each prompt only consumes its own answer
+		err := doPlan(iotest.OneByteReader(strings.NewReader(test.in)), out, fp, "")
 
 		if err != nil && !test.shouldError {
-			t.Errorf("unexpected error running command: %v", err)
+			t.Errorf("test %d: unexpected error running command: %v", i, err)
+		}
+		if err == nil && test.shouldError {
+			t.Errorf("test %d: expected an error, but didn't get one", i)
 		}
 
 		if !test.shouldError {
 			// Verify defaults are set in the plan
 			p := fp.plan
 			if p.Etcd.ExpectedCount != test.expectedEtcd {
-				t.Errorf("expected %d etcd nodes, got %d", test.expectedEtcd, p.Etcd.ExpectedCount)
+				t.Errorf("test %d: expected %d etcd nodes, got %d", i, test.expectedEtcd, p.Etcd.ExpectedCount)
 			}
 			if p.Master.ExpectedCount != test.expectedMaster {
-				t.Errorf("expected %d master nodes, got %d", test.expectedMaster, p.Master.ExpectedCount)
+				t.Errorf("test %d: expected %d master nodes, got %d", i, test.expectedMaster, p.Master.ExpectedCount)
+			}
+			if p.Cluster.StackedEtcd != test.expectedStacked {
+				t.Errorf("test %d: expected stacked etcd %t, got %t", i, test.expectedStacked, p.Cluster.StackedEtcd)
 			}
 			if p.Worker.ExpectedCount != test.expectedWorker {
-				t.Errorf("expected %d worker nodes, got %d", test.expectedWorker, p.Worker.ExpectedCount)
+				t.Errorf("test %d: expected %d worker nodes, got %d", i, test.expectedWorker, p.Worker.ExpectedCount)
+			}
+			if p.Ingress.ExpectedCount != test.expectedIngress {
+				t.Errorf("test %d: expected %d ingress nodes, got %d", i, test.expectedIngress, p.Ingress.ExpectedCount)
 			}
 		}
 	}
@@ -180,6 +180,11 @@ func doUpgrade(in io.Reader, out io.Writer, opts *upgradeOpts) error {
 		return fmt.Errorf("error listing cluster versions: %v", err)
 	}
 
+	// Verify that the plan works with the target version before touching the nodes
+	if err = validateUpgradeCompatibility(out, plan, cv); err != nil {
+		return err
+	}
+
 	// Figure out which nodes to upgrade
 	var toUpgrade []install.ListableNode
 	var toSkip []install.ListableNode
@@ -128,6 +128,17 @@ func validatePlan(out io.Writer, plan *install.Plan) error {
 	return nil
 }
 
+func validateUpgradeCompatibility(out io.Writer, plan *install.Plan, cv install.ClusterVersion) error {
+	ok, errs := install.ValidateUpgradeCompatibility(plan, cv)
+	if !ok {
+		util.PrettyPrintErr(out, "Validating plan file compatibility with Kubernetes %s", plan.Cluster.Version)
+		util.PrintValidationErrors(out, errs)
+		return fmt.Errorf("Plan file compatibility error prevents upgrade from proceeding")
+	}
+	util.PrettyPrintOk(out, "Validating plan file compatibility with Kubernetes %s", plan.Cluster.Version)
+	return nil
+}
+
 func validateSSHConnectivity(out io.Writer, plan *install.Plan) error {
 	ok, errs := install.ValidatePlanSSHConnections(plan)
 	if !ok {
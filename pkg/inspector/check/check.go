@@ -8,6 +8,13 @@ type Check interface {
 	Check() (bool, error)
 }
 
+// A DetailedCheck reports what it found on the node, such as a detected
+// version, once it has run
+type DetailedCheck interface {
+	Check
+	Details() string
+}
+
 // A ClosableCheck implements a long-running check workflow that requires closing
 type ClosableCheck interface {
 	Check
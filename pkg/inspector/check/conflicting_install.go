@@ -0,0 +1,104 @@
+package check
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Kubernetes components that are looked for by the ConflictingInstallCheck
+var (
+	conflictingExecutables = []string{"kubelet", "kubeadm", "kube-apiserver", "etcd"}
+	conflictingProcesses   = []string{"kubelet", "kube-apiserver", "kube-controller-manager", "kube-scheduler", "kube-proxy", "etcd"}
+	conflictingDirectories = []string{"/etc/kubernetes", "/var/lib/etcd"}
+)
+
+// ConflictingInstallCheck verifies that there are no remnants of an existing
+// Kubernetes installation on the node, such as binaries, running processes
+// or configuration directories.
+type ConflictingInstallCheck struct {
+	// directory that contains the process information. Defaults to /proc
+	procDir string
+	// function used to look for executables. Defaults to exec.LookPath
+	lookPath func(string) (string, error)
+	// directories that must be empty or absent. Defaults to /etc/kubernetes and /var/lib/etcd
+	directories []string
+}
+
+// Check returns true if no existing Kubernetes components were found.
+// Otherwise, returns false and an error listing what was found.
+func (c ConflictingInstallCheck) Check() (bool, error) {
+	procDir := c.procDir
+	if procDir == "" {
+		procDir = "/proc"
+	}
+	lookPath := c.lookPath
+	if lookPath == nil {
+		lookPath = exec.LookPath
+	}
+	dirs := c.directories
+	if dirs == nil {
+		dirs = conflictingDirectories
+	}
+
+	var found []string
+	for _, e := range conflictingExecutables {
+		if path, err := lookPath(e); err == nil {
+			found = append(found, fmt.Sprintf("executable %s", path))
+		}
+	}
+	running, err := runningProcesses(procDir)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range conflictingProcesses {
+		if running[p] {
+			found = append(found, fmt.Sprintf("running process %s", p))
+		}
+	}
+	for _, d := range dirs {
+		files, err := ioutil.ReadDir(d)
+		if err != nil && !os.IsNotExist(err) {
+			return false, fmt.Errorf("failed to read directory %s: %v", d, err)
+		}
+		if len(files) > 0 {
+			found = append(found, fmt.Sprintf("non-empty directory %s", d))
+		}
+	}
+	if len(found) > 0 {
+		return false, fmt.Errorf("found an existing Kubernetes installation: %s. "+
+			"Stop the running components, uninstall the Kubernetes packages and remove the directories before installing, "+
+			"or run 'kismatic reset' if the node was installed by kismatic", strings.Join(found, ", "))
+	}
+	return true, nil
+}
+
+// returns the set of process names that are running. The name is the base name
+// of the executable in /proc/<pid>/cmdline, as the kernel truncates the name in
+// /proc/<pid>/comm to 15 characters, such that kube-controller-manager becomes
+// kube-controller. The comm name is used for processes without a command line,
+// such as kernel threads.
+func runningProcesses(procDir string) (map[string]bool, error) {
+	pids, err := filepath.Glob(filepath.Join(procDir, "[0-9]*"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %v", err)
+	}
+	running := map[string]bool{}
+	for _, pid := range pids {
+		// the process might have exited since we listed it
+		if cmdline, err := ioutil.ReadFile(filepath.Join(pid, "cmdline")); err == nil {
+			argv0 := strings.SplitN(string(cmdline), "\x00", 2)[0]
+			if argv0 != "" {
+				running[filepath.Base(argv0)] = true
+				continue
+			}
+		}
+		if comm, err := ioutil.ReadFile(filepath.Join(pid, "comm")); err == nil {
+			running[strings.TrimSpace(string(comm))] = true
+		}
+	}
+	return running, nil
+}
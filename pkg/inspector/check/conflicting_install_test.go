@@ -0,0 +1,113 @@
+package check
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func notFound(string) (string, error) { return "", errors.New("not found") }
+
+func TestConflictingInstallCheckClean(t *testing.T) {
+	root, err := ioutil.TempDir("", "conflicting-install")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(root)
+	writeProc(t, root, "1", "systemd")
+	emptyDir := filepath.Join(root, "etc-kubernetes")
+	os.Mkdir(emptyDir, 0755)
+
+	c := ConflictingInstallCheck{
+		procDir:     filepath.Join(root, "proc"),
+		lookPath:    notFound,
+		directories: []string{emptyDir, filepath.Join(root, "does-not-exist")},
+	}
+	ok, err := c.Check()
+	if !ok || err != nil {
+		t.Errorf("expected the check to pass, but got %v, %v", ok, err)
+	}
+}
+
+func TestConflictingInstallCheckFound(t *testing.T) {
+	root, err := ioutil.TempDir("", "conflicting-install")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(root)
+	writeProc(t, root, "1", "systemd")
+	writeProc(t, root, "42", "kubelet")
+	dir := filepath.Join(root, "etc-kubernetes")
+	os.Mkdir(dir, 0755)
+	ioutil.WriteFile(filepath.Join(dir, "kubeconfig"), []byte{}, 0644)
+
+	c := ConflictingInstallCheck{
+		procDir: filepath.Join(root, "proc"),
+		lookPath: func(name string) (string, error) {
+			if name == "kubeadm" {
+				return "/usr/bin/kubeadm", nil
+			}
+			return "", errors.New("not found")
+		},
+		directories: []string{dir},
+	}
+	ok, err := c.Check()
+	if ok {
+		t.Fatal("expected the check to fail")
+	}
+	for _, s := range []string{"executable /usr/bin/kubeadm", "running process kubelet", "non-empty directory " + dir} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("expected the error to contain %q, but got: %v", s, err)
+		}
+	}
+}
+
+func TestConflictingInstallCheckLongProcessName(t *testing.T) {
+	root, err := ioutil.TempDir("", "conflicting-install")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(root)
+	// the kernel truncates the comm name to 15 characters
+	writeProc(t, root, "42", "kube-controller")
+	writeProcCmdline(t, root, "42", "/usr/local/bin/kube-controller-manager", "--kubeconfig=/etc/kubernetes/controller-manager.conf")
+	// kernel threads do not have a command line
+	writeProc(t, root, "2", "kthreadd")
+	writeProcCmdline(t, root, "2")
+
+	c := ConflictingInstallCheck{
+		procDir:     filepath.Join(root, "proc"),
+		lookPath:    notFound,
+		directories: []string{},
+	}
+	ok, err := c.Check()
+	if ok {
+		t.Fatal("expected the check to fail")
+	}
+	if !strings.Contains(err.Error(), "running process kube-controller-manager") {
+		t.Errorf("expected the error to contain the kube-controller-manager process, but got: %v", err)
+	}
+}
+
+func writeProcCmdline(t *testing.T, root, pid string, args ...string) {
+	cmdline := ""
+	for _, a := range args {
+		cmdline += a + "\x00"
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, "proc", pid, "cmdline"), []byte(cmdline), 0644); err != nil {
+		t.Fatalf("error writing cmdline file: %v", err)
+	}
+}
+
+func writeProc(t *testing.T, root, pid, comm string) {
+	dir := filepath.Join(root, "proc", pid)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("error creating proc dir: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "comm"), []byte(comm+"\n"), 0644); err != nil {
+		t.Fatalf("error writing comm file: %v", err)
+	}
+}
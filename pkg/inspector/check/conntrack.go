@@ -0,0 +1,50 @@
+package check
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+const nfConntrackMaxFile = "/proc/sys/net/netfilter/nf_conntrack_max"
+
+// ConntrackCheck verifies that the nf_conntrack kernel module is loaded,
+// and that the connection tracking table can hold at least the given
+// number of entries.
+type ConntrackCheck struct {
+	MinimumMax uint64
+	// file to read the table size from. Defaults to /proc/sys/net/netfilter/nf_conntrack_max
+	maxFile string
+	// used for verifying that the module is loaded
+	moduleCheck KernelModuleCheck
+}
+
+// Check returns true if nf_conntrack is loaded and net.netfilter.nf_conntrack_max
+// is greater than or equal to the minimum. Otherwise, returns false and an
+// error that explains how to fix the configuration.
+func (c ConntrackCheck) Check() (bool, error) {
+	moduleCheck := c.moduleCheck
+	moduleCheck.Module = "nf_conntrack"
+	if ok, err := moduleCheck.Check(); !ok {
+		return false, err
+	}
+	file := c.maxFile
+	if file == "" {
+		file = nfConntrackMaxFile
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the connection tracking table size from %s: %v", file, err)
+	}
+	max, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse the connection tracking table size %q: %v", strings.TrimSpace(string(b)), err)
+	}
+	if max < c.MinimumMax {
+		return false, fmt.Errorf("net.netfilter.nf_conntrack_max is %d, but at least %d is required. "+
+			"Raise the limit with 'sysctl -w net.netfilter.nf_conntrack_max=%d', and persist it across reboots "+
+			"by adding 'net.netfilter.nf_conntrack_max=%d' to a file in /etc/sysctl.d/", max, c.MinimumMax, c.MinimumMax, c.MinimumMax)
+	}
+	return true, nil
+}
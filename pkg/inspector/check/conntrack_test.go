@@ -0,0 +1,73 @@
+package check
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestConntrackCheck(t *testing.T) {
+	sysDir, err := ioutil.TempDir("", "sys-module")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(sysDir)
+	loaded := writeTempFile(t, "nf_conntrack 133053 2 ip_vs,nf_conntrack_ipv4, Live 0xffffffffc09d4000\n")
+	defer os.Remove(loaded)
+	notLoaded := writeTempFile(t, "ip_tables 27126 0 - Live 0xffffffffc0418000\n")
+	defer os.Remove(notLoaded)
+
+	tests := []struct {
+		modules string
+		max     string
+		minimum uint64
+		ok      bool
+	}{
+		{modules: loaded, max: "131072\n", minimum: 131072, ok: true},
+		{modules: loaded, max: "262144\n", minimum: 131072, ok: true},
+		{modules: loaded, max: "65536\n", minimum: 131072, ok: false},
+		{modules: loaded, max: "garbage\n", minimum: 1, ok: false},
+		{modules: notLoaded, max: "131072\n", minimum: 1, ok: false},
+	}
+	for i, test := range tests {
+		maxFile := writeTempFile(t, test.max)
+		defer os.Remove(maxFile)
+		c := ConntrackCheck{
+			MinimumMax:  test.minimum,
+			maxFile:     maxFile,
+			moduleCheck: KernelModuleCheck{modulesFile: test.modules, sysModuleDir: sysDir},
+		}
+		ok, err := c.Check()
+		if ok != test.ok {
+			t.Errorf("test %d: expected %v, but got %v (err: %v)", i, test.ok, ok, err)
+		}
+		if !ok && err == nil {
+			t.Errorf("test %d: expected an error when the check fails", i)
+		}
+	}
+}
+
+func TestConntrackCheckReportsCurrentValue(t *testing.T) {
+	sysDir, err := ioutil.TempDir("", "sys-module")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(sysDir)
+	modules := writeTempFile(t, "nf_conntrack 133053 0 - Live 0xffffffffc09d4000\n")
+	defer os.Remove(modules)
+	maxFile := writeTempFile(t, "65536\n")
+	defer os.Remove(maxFile)
+	c := ConntrackCheck{
+		MinimumMax:  131072,
+		maxFile:     maxFile,
+		moduleCheck: KernelModuleCheck{modulesFile: modules, sysModuleDir: sysDir},
+	}
+	_, err = c.Check()
+	if err == nil || !strings.Contains(err.Error(), "nf_conntrack_max is 65536") {
+		t.Errorf("expected the error to report the current value, but got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "sysctl -w net.netfilter.nf_conntrack_max=131072") {
+		t.Errorf("expected the error to include the sysctl command, but got: %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package check
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+// DomainLeakCheck verifies that a domain does not resolve through an
+// external DNS resolver.
+type DomainLeakCheck struct {
+	Domain string
+	// Resolver is the address of the external resolver. Port 53 is used
+	// if no port is given.
+	Resolver string
+	// Timeout is the maximum amount of time the check will wait for the
+	// resolver to answer. Defaults to 10 seconds.
+	Timeout time.Duration
+
+	lookup func(ctx context.Context, host string) ([]string, error)
+}
+
+// Check returns true if the resolver does not know about the domain. An
+// error is returned if the domain resolved, or if the resolver could not
+// be queried.
+func (c DomainLeakCheck) Check() (bool, error) {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	lookup := c.lookup
+	if lookup == nil {
+		lookup = c.lookupHost
+	}
+	// query the fully qualified name, so that the search domains of the
+	// node are not appended to it
+	addrs, err := lookup(ctx, strings.TrimSuffix(c.Domain, ".")+".")
+	if err != nil {
+		if dnsErr, ok := err.(*net.DNSError); ok && !dnsErr.IsTimeout && !dnsErr.IsTemporary {
+			return true, nil
+		}
+		return false, fmt.Errorf("could not query %s for %s: %v", c.Resolver, c.Domain, err)
+	}
+	if len(addrs) == 0 {
+		return true, nil
+	}
+	return false, fmt.Errorf("%s resolved to %s through %s", c.Domain, strings.Join(addrs, ", "), c.Resolver)
+}
+
+// lookupHost returns the IPv4 and IPv6 addresses of the host, as answered by
+// the external resolver. Unlike net.Resolver, the hosts file of the node is
+// not consulted.
+func (c DomainLeakCheck) lookupHost(ctx context.Context, host string) ([]string, error) {
+	var addrs []string
+	for _, qtype := range []uint16{dnsTypeA, dnsTypeAAAA} {
+		a, err := c.query(ctx, host, qtype)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, a...)
+	}
+	return addrs, nil
+}
+
+const (
+	dnsTypeA    = 1
+	dnsTypeAAAA = 28
+
+	dnsRcodeNameError = 3
+)
+
+var dnsRcodeNames = map[byte]string{
+	1: "FORMERR",
+	2: "SERVFAIL",
+	4: "NOTIMP",
+	5: "REFUSED",
+}
+
+// query sends a single question to the external resolver, and returns the
+// addresses in the answer
+func (c DomainLeakCheck) query(ctx context.Context, host string, qtype uint16) ([]string, error) {
+	addr := c.Resolver
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "53")
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	id := uint16(rand.Intn(1 << 16))
+	msg, err := dnsQuestion(id, host, qtype)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = conn.Write(msg); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 512)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				return nil, &net.DNSError{Err: "i/o timeout", Name: host, Server: addr, IsTimeout: true}
+			}
+			return nil, err
+		}
+		// ignore stray responses to other queries
+		if n < 12 || binary.BigEndian.Uint16(buf) != id {
+			continue
+		}
+		return dnsAnswer(buf[:n], host, addr)
+	}
+}
+
+// dnsQuestion returns a recursive query for the records of the given type
+func dnsQuestion(id uint16, host string, qtype uint16) ([]byte, error) {
+	msg := make([]byte, 12, 512)
+	binary.BigEndian.PutUint16(msg, id)
+	binary.BigEndian.PutUint16(msg[2:], 0x0100) // recursion desired
+	binary.BigEndian.PutUint16(msg[4:], 1)      // one question
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return nil, fmt.Errorf("invalid domain name %q", host)
+		}
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0, byte(qtype>>8), byte(qtype), 0, 1) // class IN
+	return msg, nil
+}
+
+// dnsAnswer returns the addresses in the answer section of the response
+func dnsAnswer(msg []byte, host, server string) ([]string, error) {
+	switch rcode := msg[3] & 0x0f; rcode {
+	case 0:
+	case dnsRcodeNameError:
+		return nil, &net.DNSError{Err: "no such host", Name: host, Server: server}
+	default:
+		// only a name error or an answer tells that the domain is unknown,
+		// so the query failed for any other code, such as a refused query
+		name, ok := dnsRcodeNames[rcode]
+		if !ok {
+			name = "unknown error"
+		}
+		return nil, fmt.Errorf("server returned error code %d (%s)", rcode, name)
+	}
+	malformed := &net.DNSError{Err: "malformed response", Name: host, Server: server, IsTemporary: true}
+	qdcount := int(binary.BigEndian.Uint16(msg[4:]))
+	ancount := int(binary.BigEndian.Uint16(msg[6:]))
+	off := 12
+	var ok bool
+	for i := 0; i < qdcount; i++ {
+		if off, ok = skipDNSName(msg, off); !ok || off+4 > len(msg) {
+			return nil, malformed
+		}
+		off += 4
+	}
+	var addrs []string
+	for i := 0; i < ancount; i++ {
+		if off, ok = skipDNSName(msg, off); !ok || off+10 > len(msg) {
+			return nil, malformed
+		}
+		rtype := binary.BigEndian.Uint16(msg[off:])
+		rdlength := int(binary.BigEndian.Uint16(msg[off+8:]))
+		off += 10
+		if off+rdlength > len(msg) {
+			return nil, malformed
+		}
+		rdata := msg[off : off+rdlength]
+		off += rdlength
+		if (rtype == dnsTypeA && rdlength == net.IPv4len) || (rtype == dnsTypeAAAA && rdlength == net.IPv6len) {
+			addrs = append(addrs, net.IP(rdata).String())
+		}
+	}
+	return addrs, nil
+}
+
+// skipDNSName returns the offset that follows the name at the given offset
+func skipDNSName(msg []byte, off int) (int, bool) {
+	for off < len(msg) {
+		l := int(msg[off])
+		switch {
+		case l == 0:
+			return off + 1, true
+		case l&0xc0 == 0xc0: // compression pointer
+			return off + 2, off+2 <= len(msg)
+		}
+		off += 1 + l
+	}
+	return off, false
+}
@@ -0,0 +1,127 @@
+package check
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestDomainLeakCheck(t *testing.T) {
+	tests := []struct {
+		addrs []string
+		err   error
+		ok    bool
+	}{
+		{err: &net.DNSError{Err: "no such host", Name: "cluster.local"}, ok: true},
+		{addrs: []string{}, ok: true},
+		{addrs: []string{"10.0.0.1"}, ok: false},
+		{err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}, ok: false},
+		{err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}, ok: false},
+		{err: errors.New("connection refused"), ok: false},
+	}
+	for i, test := range tests {
+		c := DomainLeakCheck{
+			Domain:   "cluster.local",
+			Resolver: "8.8.8.8",
+			lookup: func(context.Context, string) ([]string, error) {
+				return test.addrs, test.err
+			},
+		}
+		ok, err := c.Check()
+		if ok != test.ok {
+			t.Errorf("test %d: expected %v, but got %v (err: %v)", i, test.ok, ok, err)
+		}
+		if !ok && err == nil {
+			t.Errorf("test %d: expected an error when the check fails", i)
+		}
+	}
+}
+
+func TestDomainLeakCheckReportsResolution(t *testing.T) {
+	c := DomainLeakCheck{
+		Domain:   "cluster.local",
+		Resolver: "8.8.8.8",
+		lookup: func(context.Context, string) ([]string, error) {
+			return []string{"10.0.0.1", "10.0.0.2"}, nil
+		},
+	}
+	_, err := c.Check()
+	if err == nil || !strings.Contains(err.Error(), "10.0.0.1, 10.0.0.2") {
+		t.Errorf("expected the error to contain the resolved addresses, but got %v", err)
+	}
+}
+
+func TestDomainLeakCheckQueriesFullyQualifiedName(t *testing.T) {
+	var queried string
+	c := DomainLeakCheck{
+		Domain:   "cluster.local",
+		Resolver: "8.8.8.8",
+		lookup: func(_ context.Context, host string) ([]string, error) {
+			queried = host
+			return nil, nil
+		},
+	}
+	c.Check()
+	if queried != "cluster.local." {
+		t.Errorf("expected the lookup of %q, but got %q", "cluster.local.", queried)
+	}
+}
+
+// fakeResolver answers every question with the given response code, and an
+// A record for 10.0.0.1 when answer is true
+func fakeResolver(t *testing.T, rcode byte, answer bool) (string, func()) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			resp := append([]byte{}, buf[:n]...)
+			resp[2] |= 0x80 // response
+			resp[3] = rcode
+			qtype := binary.BigEndian.Uint16(resp[n-4:])
+			if answer && qtype == 1 {
+				binary.BigEndian.PutUint16(resp[6:], 1)
+				resp = append(resp, 0xc0, 12, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4, 10, 0, 0, 1)
+			}
+			conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String(), func() { conn.Close() }
+}
+
+func TestDomainLeakCheckExternalResolver(t *testing.T) {
+	tests := []struct {
+		rcode  byte
+		answer bool
+		ok     bool
+		err    string
+	}{
+		{rcode: 0, answer: true, ok: false, err: "10.0.0.1"},
+		{rcode: 0, ok: true},
+		{rcode: 3, ok: true},
+		{rcode: 2, ok: false, err: "SERVFAIL"},
+		{rcode: 4, ok: false, err: "NOTIMP"},
+		{rcode: 5, ok: false, err: "REFUSED"},
+	}
+	for i, test := range tests {
+		addr, stop := fakeResolver(t, test.rcode, test.answer)
+		c := DomainLeakCheck{Domain: "localhost", Resolver: addr}
+		ok, err := c.Check()
+		stop()
+		if ok != test.ok {
+			t.Errorf("test %d: expected %v, but got %v (err: %v)", i, test.ok, ok, err)
+		}
+		if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("test %d: expected the error to contain %q, but got %v", i, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package check
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+)
+
+// HTTPReachableCheck verifies that an HTTP endpoint can be reached from
+// the node.
+type HTTPReachableCheck struct {
+	URL string
+	// Timeout is the maximum amount of time the check will wait for a
+	// response. Defaults to 10 seconds.
+	Timeout time.Duration
+	// CertsDir is a directory with a sub-directory of CA certificates for
+	// each host, such as /etc/docker/certs.d/mirror.example.com:5000/ca.crt.
+	// The certificates are trusted in addition to the system's.
+	CertsDir string
+}
+
+// Check returns true if the endpoint responded to a GET request. Endpoints
+// that require authentication are considered reachable, but server errors
+// are not.
+func (c HTTPReachableCheck) Check() (bool, error) {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	client := http.Client{Timeout: timeout}
+	if c.CertsDir != "" {
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return false, err
+		}
+		if tlsConfig != nil {
+			client.Transport = &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig}
+		}
+	}
+	resp, err := client.Get(c.URL)
+	if err != nil {
+		return false, fmt.Errorf("%s is unreachable: %v", c.URL, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return false, fmt.Errorf("%s responded with status %q", c.URL, resp.Status)
+	}
+	return true, nil
+}
+
+// tlsConfig returns a config that trusts the CA certificates of the host,
+// in the same way as docker
+func (c HTTPReachableCheck) tlsConfig() (*tls.Config, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %v", c.URL, err)
+	}
+	files, err := filepath.Glob(filepath.Join(c.CertsDir, u.Host, "*.crt"))
+	if err != nil || len(files) == 0 {
+		return nil, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	for _, f := range files {
+		pem, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA certificate %q: %v", f, err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %q", f)
+		}
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
@@ -0,0 +1,77 @@
+package check
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHTTPReachableCheck(t *testing.T) {
+	tests := []struct {
+		status int
+		ok     bool
+	}{
+		{status: http.StatusOK, ok: true},
+		{status: http.StatusUnauthorized, ok: true},
+		{status: http.StatusNotFound, ok: true},
+		{status: http.StatusBadGateway, ok: false},
+	}
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+		}))
+		c := HTTPReachableCheck{URL: server.URL + "/v2/"}
+		ok, err := c.Check()
+		if ok != test.ok {
+			t.Errorf("status %d: expected %v, but got %v (err: %v)", test.status, test.ok, ok, err)
+		}
+		server.Close()
+	}
+}
+
+func TestHTTPReachableCheckUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+	c := HTTPReachableCheck{URL: url, Timeout: time.Second}
+	ok, err := c.Check()
+	if ok || err == nil {
+		t.Errorf("expected the check to fail with an error, but got %v, %v", ok, err)
+	}
+}
+
+func TestHTTPReachableCheckCertsDir(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("error parsing URL: %v", err)
+	}
+	dir, err := ioutil.TempDir("", "http-check-test")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := HTTPReachableCheck{URL: server.URL + "/v2/", CertsDir: dir}
+	if ok, err := c.Check(); ok || err == nil {
+		t.Errorf("expected the check to fail without the CA certificate, but got %v, %v", ok, err)
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, u.Host), 0755); err != nil {
+		t.Fatalf("error creating certs dir: %v", err)
+	}
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := ioutil.WriteFile(filepath.Join(dir, u.Host, "ca.crt"), ca, 0644); err != nil {
+		t.Fatalf("error writing CA certificate: %v", err)
+	}
+	if ok, err := c.Check(); !ok {
+		t.Errorf("expected the check to pass with the CA certificate, but got %v", err)
+	}
+}
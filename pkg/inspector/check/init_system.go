@@ -0,0 +1,70 @@
+package check
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	Systemd           InitSystem = "systemd"
+	SysVinit          InitSystem = "sysvinit"
+	OpenRC            InitSystem = "openrc"
+	UnknownInitSystem InitSystem = ""
+)
+
+// InitSystem is the init system that manages the services of a node
+type InitSystem string
+
+// DetectInitSystem returns the init system that is running on the node.
+func DetectInitSystem() (InitSystem, error) {
+	return detectInitSystem("/")
+}
+
+// detectInitSystem looks for the run-time markers of each init system
+// under the given root directory
+func detectInitSystem(root string) (InitSystem, error) {
+	// this is how sd_booted(3) detects systemd
+	if isDir(filepath.Join(root, "run/systemd/system")) {
+		return Systemd, nil
+	}
+	if isDir(filepath.Join(root, "run/openrc")) {
+		return OpenRC, nil
+	}
+	if _, err := os.Stat(filepath.Join(root, "etc/inittab")); err == nil {
+		return SysVinit, nil
+	}
+	return UnknownInitSystem, fmt.Errorf("unable to detect the init system")
+}
+
+func isDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+// InitSystemCheck verifies that the node is running one of the supported
+// init systems
+type InitSystemCheck struct {
+	SupportedInitSystems []string
+	// root directory used for detecting the init system. Defaults to /
+	root string
+}
+
+// Check returns true if the init system running on the node is supported
+func (c InitSystemCheck) Check() (bool, error) {
+	root := c.root
+	if root == "" {
+		root = "/"
+	}
+	initSystem, err := detectInitSystem(root)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range c.SupportedInitSystems {
+		if string(initSystem) == s {
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("init system %q is not supported. Supported init systems are: %s", initSystem, strings.Join(c.SupportedInitSystems, ", "))
+}
@@ -0,0 +1,81 @@
+package check
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectInitSystem(t *testing.T) {
+	tests := []struct {
+		dirs     []string
+		files    []string
+		expected InitSystem
+		err      bool
+	}{
+		{dirs: []string{"run/systemd/system"}, expected: Systemd},
+		{dirs: []string{"run/systemd/system"}, files: []string{"etc/inittab"}, expected: Systemd},
+		{dirs: []string{"run/openrc"}, files: []string{"etc/inittab"}, expected: OpenRC},
+		{files: []string{"etc/inittab"}, expected: SysVinit},
+		// systemd is installed, but is not the running init system
+		{dirs: []string{"run/systemd"}, expected: UnknownInitSystem, err: true},
+		{expected: UnknownInitSystem, err: true},
+	}
+	for i, test := range tests {
+		root := initSystemRoot(t, test.dirs, test.files)
+		defer os.RemoveAll(root)
+		initSystem, err := detectInitSystem(root)
+		if initSystem != test.expected {
+			t.Errorf("test %d: expected %q, but got %q", i, test.expected, initSystem)
+		}
+		if (err != nil) != test.err {
+			t.Errorf("test %d: expected error %v, but got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestInitSystemCheck(t *testing.T) {
+	root := initSystemRoot(t, []string{"run/openrc"}, nil)
+	defer os.RemoveAll(root)
+	tests := []struct {
+		supported []string
+		ok        bool
+	}{
+		{supported: []string{"systemd"}, ok: false},
+		{supported: []string{"systemd", "openrc"}, ok: true},
+		{supported: []string{}, ok: false},
+	}
+	for _, test := range tests {
+		c := InitSystemCheck{SupportedInitSystems: test.supported, root: root}
+		ok, err := c.Check()
+		if ok != test.ok {
+			t.Errorf("supported %v: expected %v, but got %v (err: %v)", test.supported, test.ok, ok, err)
+		}
+		if !ok && err == nil {
+			t.Errorf("supported %v: expected an error when the check fails", test.supported)
+		}
+	}
+}
+
+func initSystemRoot(t *testing.T, dirs []string, files []string) string {
+	root, err := ioutil.TempDir("", "init-system")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	for _, d := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, d), 0755); err != nil {
+			t.Fatalf("error creating dir: %v", err)
+		}
+	}
+	for _, f := range files {
+		p := filepath.Join(root, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("error creating dir: %v", err)
+		}
+		if err := ioutil.WriteFile(p, []byte{}, 0644); err != nil {
+			t.Fatalf("error writing file: %v", err)
+		}
+	}
+	return root
+}
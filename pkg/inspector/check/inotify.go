@@ -0,0 +1,42 @@
+package check
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+const inotifyMaxUserWatchesFile = "/proc/sys/fs/inotify/max_user_watches"
+
+// InotifyCheck verifies that the kernel allows each user to hold at least
+// the given number of inotify watches.
+type InotifyCheck struct {
+	MinimumWatches uint64
+	// file to read the limit from. Defaults to /proc/sys/fs/inotify/max_user_watches
+	file string
+}
+
+// Check returns true if fs.inotify.max_user_watches is greater than or equal
+// to the minimum. Otherwise, returns false and an error that explains how
+// to raise the limit.
+func (c InotifyCheck) Check() (bool, error) {
+	file := c.file
+	if file == "" {
+		file = inotifyMaxUserWatchesFile
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the inotify watch limit from %s: %v", file, err)
+	}
+	watches, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse the inotify watch limit %q: %v", strings.TrimSpace(string(b)), err)
+	}
+	if watches < c.MinimumWatches {
+		return false, fmt.Errorf("fs.inotify.max_user_watches is %d, but at least %d is required. "+
+			"Raise the limit with 'sysctl -w fs.inotify.max_user_watches=%d', and persist it across reboots "+
+			"by adding 'fs.inotify.max_user_watches=%d' to a file in /etc/sysctl.d/", watches, c.MinimumWatches, c.MinimumWatches, c.MinimumWatches)
+	}
+	return true, nil
+}
@@ -0,0 +1,67 @@
+package check
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func writeTempFile(t *testing.T, contents string) string {
+	f, err := ioutil.TempFile("", "inotify")
+	if err != nil {
+		t.Fatalf("error creating temp file: %v", err)
+	}
+	f.WriteString(contents)
+	f.Close()
+	return f.Name()
+}
+
+func TestInotifyCheck(t *testing.T) {
+	tests := []struct {
+		contents string
+		minimum  uint64
+		ok       bool
+	}{
+		{contents: "524288\n", minimum: 1048576, ok: false},
+		{contents: "524288\n", minimum: 524288, ok: true},
+		{contents: "8192\n", minimum: 1, ok: true},
+		{contents: "garbage\n", minimum: 1, ok: false},
+	}
+	for i, test := range tests {
+		file := writeTempFile(t, test.contents)
+		defer os.Remove(file)
+		c := InotifyCheck{MinimumWatches: test.minimum, file: file}
+		ok, err := c.Check()
+		if ok != test.ok {
+			t.Errorf("test %d: expected %v, but got %v (err: %v)", i, test.ok, ok, err)
+		}
+		if !ok && err == nil {
+			t.Errorf("test %d: expected an error when the check fails", i)
+		}
+	}
+}
+
+func TestInotifyCheckRemediation(t *testing.T) {
+	file := writeTempFile(t, "8192")
+	defer os.Remove(file)
+	c := InotifyCheck{MinimumWatches: 524288, file: file}
+	_, err := c.Check()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "sysctl -w fs.inotify.max_user_watches=524288") {
+		t.Errorf("expected the error to include the sysctl command, but got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "/etc/sysctl.d/") {
+		t.Errorf("expected the error to include the persistent config, but got: %v", err)
+	}
+}
+
+func TestInotifyCheckMissingFile(t *testing.T) {
+	c := InotifyCheck{MinimumWatches: 1, file: "/non/existent/file"}
+	ok, err := c.Check()
+	if ok || err == nil {
+		t.Errorf("expected the check to fail with an error, but got %v, %v", ok, err)
+	}
+}
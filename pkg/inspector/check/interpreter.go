@@ -0,0 +1,105 @@
+package check
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	interpreterNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+	versionRegexp         = regexp.MustCompile(`(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+)
+
+// InterpreterCheck verifies that an interpreter, such as python, is installed
+// on the node and that its version is greater than or equal to the minimum.
+type InterpreterCheck struct {
+	Name           string
+	MinimumVersion string
+	// runs the interpreter with the version flag. Defaults to running "<name> --version".
+	versionOutput func(name string) ([]byte, error)
+	detected      string
+}
+
+// Check returns true if the interpreter is installed and meets the minimum
+// version. The detected version is included in the error when the
+// version is too old, and is available from Details in all cases.
+func (c *InterpreterCheck) Check() (bool, error) {
+	c.detected = ""
+	if !interpreterNameRegexp.MatchString(c.Name) {
+		return false, fmt.Errorf("invalid interpreter name used in check: %s. Names must adhere to the following regexp: %s", c.Name, interpreterNameRegexp.String())
+	}
+	minimum, err := ParseVersion(c.MinimumVersion)
+	if err != nil {
+		return false, err
+	}
+	versionOutput := c.versionOutput
+	if versionOutput == nil {
+		versionOutput = func(name string) ([]byte, error) {
+			// python 2 prints its version to stderr
+			return exec.Command(name, "--version").CombinedOutput()
+		}
+	}
+	out, err := versionOutput(c.Name)
+	if err != nil {
+		return false, fmt.Errorf("%s doesn't seem to be installed: %v", c.Name, err)
+	}
+	detected, err := ParseVersion(string(out))
+	if err != nil {
+		return false, fmt.Errorf("could not determine the version of %s from %q", c.Name, strings.TrimSpace(string(out)))
+	}
+	c.detected = formatVersion(detected)
+	if compareVersions(detected, minimum) < 0 {
+		return false, fmt.Errorf("%s version %s was detected, but at least %s is required", c.Name, formatVersion(detected), c.MinimumVersion)
+	}
+	return true, nil
+}
+
+// Details returns the version of the interpreter that was detected by the
+// last run of the check, if any
+func (c *InterpreterCheck) Details() string {
+	if c.detected == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s version %s was detected", c.Name, c.detected)
+}
+
+// ParseVersion returns the major, minor and patch numbers of the first
+// version found in the string. The minor and patch numbers are optional,
+// and default to 0.
+func ParseVersion(s string) ([3]int, error) {
+	var v [3]int
+	m := versionRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return v, fmt.Errorf("%q does not contain a version", s)
+	}
+	for i := 0; i < 3; i++ {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return v, fmt.Errorf("%q does not contain a valid version: %v", s, err)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := 0; i < 3; i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func formatVersion(v [3]int) string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
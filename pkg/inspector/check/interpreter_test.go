@@ -0,0 +1,100 @@
+package check
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInterpreterCheck(t *testing.T) {
+	tests := []struct {
+		output  string
+		err     error
+		minimum string
+		ok      bool
+	}{
+		{output: "Python 3.6.8\n", minimum: "3.5", ok: true},
+		{output: "Python 3.6.8\n", minimum: "3.6.8", ok: true},
+		{output: "Python 3.6.8\n", minimum: "3", ok: true},
+		{output: "Python 2.7.5\n", minimum: "3", ok: false},
+		{output: "Python 3.4.10\n", minimum: "3.5", ok: false},
+		{output: "no version here", minimum: "3.5", ok: false},
+		{err: errors.New("executable file not found in $PATH"), minimum: "3.5", ok: false},
+	}
+	for i, test := range tests {
+		c := InterpreterCheck{
+			Name:           "python3",
+			MinimumVersion: test.minimum,
+			versionOutput: func(string) ([]byte, error) {
+				return []byte(test.output), test.err
+			},
+		}
+		ok, err := c.Check()
+		if ok != test.ok {
+			t.Errorf("test %d: expected %v, but got %v (err: %v)", i, test.ok, ok, err)
+		}
+		if !ok && err == nil {
+			t.Errorf("test %d: expected an error when the check fails", i)
+		}
+	}
+}
+
+func TestInterpreterCheckReportsDetectedVersion(t *testing.T) {
+	c := InterpreterCheck{
+		Name:           "python3",
+		MinimumVersion: "3.5",
+		versionOutput: func(string) ([]byte, error) {
+			return []byte("Python 3.4.10"), nil
+		},
+	}
+	_, err := c.Check()
+	if err == nil || !strings.Contains(err.Error(), "3.4.10") {
+		t.Errorf("expected the error to contain the detected version, but got: %v", err)
+	}
+}
+
+func TestInterpreterCheckDetailsOnSuccess(t *testing.T) {
+	c := InterpreterCheck{
+		Name:           "python3",
+		MinimumVersion: "3.5",
+		versionOutput: func(string) ([]byte, error) {
+			return []byte("Python 3.6.8"), nil
+		},
+	}
+	if ok, err := c.Check(); !ok {
+		t.Fatalf("expected the check to pass, but got: %v", err)
+	}
+	if d := c.Details(); d != "python3 version 3.6.8 was detected" {
+		t.Errorf("expected the details to contain the detected version, but got %q", d)
+	}
+}
+
+func TestInterpreterCheckInvalidName(t *testing.T) {
+	c := InterpreterCheck{Name: "python; rm -rf /", MinimumVersion: "3"}
+	if ok, err := c.Check(); ok || err == nil {
+		t.Errorf("expected the check to fail with an error, but got %v, %v", ok, err)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected [3]int
+		valid    bool
+	}{
+		{s: "3", expected: [3]int{3, 0, 0}, valid: true},
+		{s: "3.6", expected: [3]int{3, 6, 0}, valid: true},
+		{s: "Python 3.6.8", expected: [3]int{3, 6, 8}, valid: true},
+		{s: "no version", valid: false},
+	}
+	for _, test := range tests {
+		v, err := ParseVersion(test.s)
+		if (err == nil) != test.valid {
+			t.Errorf("%q: expected valid=%t, but got error %v", test.s, test.valid, err)
+			continue
+		}
+		if test.valid && v != test.expected {
+			t.Errorf("%q: expected %v, but got %v", test.s, test.expected, v)
+		}
+	}
+}
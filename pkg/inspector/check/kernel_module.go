@@ -0,0 +1,58 @@
+package check
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	procModulesFile = "/proc/modules"
+	sysModuleDir    = "/sys/module"
+)
+
+// KernelModuleCheck verifies that a kernel module is loaded, or built into
+// the kernel.
+type KernelModuleCheck struct {
+	Module string
+	// file to read the loaded modules from. Defaults to /proc/modules
+	modulesFile string
+	// directory that lists the modules known to the kernel. Defaults to /sys/module
+	sysModuleDir string
+}
+
+// Check returns true if the kernel module is loaded. Otherwise, returns false
+// and an error that explains how to load the module.
+func (c KernelModuleCheck) Check() (bool, error) {
+	file := c.modulesFile
+	if file == "" {
+		file = procModulesFile
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the loaded kernel modules from %s: %v", file, err)
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) > 0 && fields[0] == c.Module {
+			return true, nil
+		}
+	}
+	if err := s.Err(); err != nil {
+		return false, fmt.Errorf("failed to read the loaded kernel modules from %s: %v", file, err)
+	}
+	// Modules that are built into the kernel are not listed in /proc/modules
+	dir := c.sysModuleDir
+	if dir == "" {
+		dir = sysModuleDir
+	}
+	if _, err := os.Stat(filepath.Join(dir, c.Module)); err == nil {
+		return true, nil
+	}
+	return false, fmt.Errorf("kernel module %q is not loaded. Load it with 'modprobe %s', and load it on boot "+
+		"by adding %q to a file in /etc/modules-load.d/", c.Module, c.Module, c.Module)
+}
@@ -0,0 +1,46 @@
+package check
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testModules = `ip_vs_sh 12688 0 - Live 0xffffffffc0a46000
+ip_vs 141432 2 ip_vs_sh, Live 0xffffffffc0a1f000
+nf_conntrack 133053 2 ip_vs,nf_conntrack_ipv4, Live 0xffffffffc09d4000
+`
+
+func TestKernelModuleCheck(t *testing.T) {
+	file := writeTempFile(t, testModules)
+	defer os.Remove(file)
+	sysDir, err := ioutil.TempDir("", "sys-module")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(sysDir)
+	if err := os.Mkdir(filepath.Join(sysDir, "nf_conntrack_ipv4"), 0755); err != nil {
+		t.Fatalf("error creating module dir: %v", err)
+	}
+	tests := []struct {
+		module string
+		ok     bool
+	}{
+		{module: "ip_vs", ok: true},
+		{module: "ip_vs_sh", ok: true},
+		{module: "nf_conntrack_ipv4", ok: true},
+		{module: "ip_vs_rr", ok: false},
+		{module: "ip_v", ok: false},
+	}
+	for _, test := range tests {
+		c := KernelModuleCheck{Module: test.module, modulesFile: file, sysModuleDir: sysDir}
+		ok, err := c.Check()
+		if ok != test.ok {
+			t.Errorf("module %q: expected %v, but got %v (err: %v)", test.module, test.ok, ok, err)
+		}
+		if !ok && err == nil {
+			t.Errorf("module %q: expected an error when the check fails", test.module)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package check
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	productUUIDFile = "/sys/class/dmi/id/product_uuid"
+	sysNetDir       = "/sys/class/net"
+)
+
+// product UUIDs that firmware reports when the UUID was never set. Every
+// machine built from the same image reports the same value.
+var placeholderProductUUIDs = []string{
+	"00000000-0000-0000-0000-000000000000",
+	"ffffffff-ffff-ffff-ffff-ffffffffffff",
+	"03000200-0400-0500-0006-000700080009",
+}
+
+// MachineID contains the identifiers that must be unique across the nodes
+// of a cluster
+type MachineID struct {
+	// ProductUUID is empty when the machine does not report one, such as
+	// ARM boards and Xen PV guests
+	ProductUUID string
+	// MACAddresses of the physical network interfaces, keyed by interface name
+	MACAddresses map[string]string
+}
+
+// MachineIDCheck verifies that the node's product UUID and the MAC
+// addresses of its network interfaces are usable as unique identifiers.
+// Whether they are unique across the cluster can only be verified by
+// comparing the MachineID of every node.
+type MachineIDCheck struct {
+	// file to read the product UUID from. Defaults to /sys/class/dmi/id/product_uuid
+	productUUIDFile string
+	// directory that lists the network interfaces. Defaults to /sys/class/net
+	netDir string
+}
+
+// Check returns true if the node's product UUID is not a placeholder, and
+// none of its network interfaces share a MAC address. Nodes that do not
+// report a product UUID are only checked for shared MAC addresses.
+func (c MachineIDCheck) Check() (bool, error) {
+	id, err := c.MachineID()
+	if err != nil {
+		return false, err
+	}
+	for _, p := range placeholderProductUUIDs {
+		if strings.ToLower(id.ProductUUID) == p {
+			return false, fmt.Errorf("product UUID %s is a placeholder that is shared by machines built from the same template. "+
+				"Configure a unique UUID for the machine", id.ProductUUID)
+		}
+	}
+	byMAC := map[string][]string{}
+	for iface, mac := range id.MACAddresses {
+		byMAC[mac] = append(byMAC[mac], iface)
+	}
+	for mac, ifaces := range byMAC {
+		if len(ifaces) > 1 {
+			sort.Strings(ifaces)
+			return false, fmt.Errorf("network interfaces %s share the MAC address %s", strings.Join(ifaces, ", "), mac)
+		}
+	}
+	return true, nil
+}
+
+// MachineID returns the product UUID and the MAC addresses of the node's
+// physical network interfaces
+func (c MachineIDCheck) MachineID() (*MachineID, error) {
+	uuidFile := c.productUUIDFile
+	if uuidFile == "" {
+		uuidFile = productUUIDFile
+	}
+	netDir := c.netDir
+	if netDir == "" {
+		netDir = sysNetDir
+	}
+	id := &MachineID{MACAddresses: map[string]string{}}
+	b, err := ioutil.ReadFile(uuidFile)
+	switch {
+	case os.IsNotExist(err):
+		// not every platform exposes the DMI product UUID
+	case err != nil:
+		return nil, fmt.Errorf("failed to read the product UUID from %s: %v", uuidFile, err)
+	default:
+		id.ProductUUID = strings.TrimSpace(string(b))
+	}
+	ifaces, err := ioutil.ReadDir(netDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the network interfaces in %s: %v", netDir, err)
+	}
+	for _, iface := range ifaces {
+		dir := filepath.Join(netDir, iface.Name())
+		// only physical interfaces have a device. Loopback, bridges, bonds and
+		// veths are skipped, as they legitimately reuse MAC addresses.
+		if _, err := os.Stat(filepath.Join(dir, "device")); err != nil {
+			continue
+		}
+		// the address of a bonded interface is the address of the bond
+		addrFile := filepath.Join(dir, "bonding_slave", "perm_hwaddr")
+		if _, err := os.Stat(addrFile); err != nil {
+			addrFile = filepath.Join(dir, "address")
+		}
+		b, err := ioutil.ReadFile(addrFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the MAC address of %s: %v", iface.Name(), err)
+		}
+		id.MACAddresses[iface.Name()] = strings.ToLower(strings.TrimSpace(string(b)))
+	}
+	return id, nil
+}
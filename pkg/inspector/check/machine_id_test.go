@@ -0,0 +1,155 @@
+package check
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testInterface struct {
+	name     string
+	address  string
+	physical bool
+	// permanent address of a bonded interface
+	permAddress string
+}
+
+func machineIDDir(t *testing.T, ifaces []testInterface) string {
+	dir, err := ioutil.TempDir("", "sys-class-net")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	for _, iface := range ifaces {
+		ifaceDir := filepath.Join(dir, iface.name)
+		if err := os.Mkdir(ifaceDir, 0755); err != nil {
+			t.Fatalf("error creating interface dir: %v", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(ifaceDir, "address"), []byte(iface.address+"\n"), 0644); err != nil {
+			t.Fatalf("error writing address: %v", err)
+		}
+		if iface.physical {
+			if err := os.Mkdir(filepath.Join(ifaceDir, "device"), 0755); err != nil {
+				t.Fatalf("error creating device dir: %v", err)
+			}
+		}
+		if iface.permAddress != "" {
+			if err := os.Mkdir(filepath.Join(ifaceDir, "bonding_slave"), 0755); err != nil {
+				t.Fatalf("error creating bonding_slave dir: %v", err)
+			}
+			if err := ioutil.WriteFile(filepath.Join(ifaceDir, "bonding_slave", "perm_hwaddr"), []byte(iface.permAddress+"\n"), 0644); err != nil {
+				t.Fatalf("error writing perm_hwaddr: %v", err)
+			}
+		}
+	}
+	return dir
+}
+
+func TestMachineIDCheck(t *testing.T) {
+	tests := []struct {
+		uuid   string
+		ifaces []testInterface
+		ok     bool
+	}{
+		{
+			uuid: "EC2A5A4E-1C6B-9C3E-2B6B-3A5E7C1F9D22",
+			ifaces: []testInterface{
+				{name: "lo", address: "00:00:00:00:00:00"},
+				{name: "eth0", address: "0a:1b:2c:3d:4e:5f", physical: true},
+				{name: "eth1", address: "0a:1b:2c:3d:4e:60", physical: true},
+				// virtual interfaces can share addresses
+				{name: "docker0", address: "02:42:ac:11:00:01"},
+				{name: "veth1", address: "02:42:ac:11:00:01"},
+			},
+			ok: true,
+		},
+		{
+			uuid: "EC2A5A4E-1C6B-9C3E-2B6B-3A5E7C1F9D22",
+			ifaces: []testInterface{
+				{name: "eth0", address: "0a:1b:2c:3d:4e:5f", physical: true},
+				{name: "eth1", address: "0A:1B:2C:3D:4E:5F", physical: true},
+			},
+			ok: false,
+		},
+		{
+			uuid: "EC2A5A4E-1C6B-9C3E-2B6B-3A5E7C1F9D22",
+			ifaces: []testInterface{
+				{name: "bond0", address: "0a:1b:2c:3d:4e:5f"},
+				{name: "eth0", address: "0a:1b:2c:3d:4e:5f", physical: true, permAddress: "0a:1b:2c:3d:4e:5f"},
+				{name: "eth1", address: "0a:1b:2c:3d:4e:5f", physical: true, permAddress: "0a:1b:2c:3d:4e:60"},
+			},
+			ok: true,
+		},
+		{
+			uuid: "03000200-0400-0500-0006-000700080009",
+			ifaces: []testInterface{
+				{name: "eth0", address: "0a:1b:2c:3d:4e:5f", physical: true},
+			},
+			ok: false,
+		},
+	}
+	for i, test := range tests {
+		netDir := machineIDDir(t, test.ifaces)
+		defer os.RemoveAll(netDir)
+		uuidFile := writeTempFile(t, test.uuid+"\n")
+		defer os.Remove(uuidFile)
+		c := MachineIDCheck{productUUIDFile: uuidFile, netDir: netDir}
+		ok, err := c.Check()
+		if ok != test.ok {
+			t.Errorf("test %d: expected %v, but got %v (err: %v)", i, test.ok, ok, err)
+		}
+		if !ok && err == nil {
+			t.Errorf("test %d: expected an error when the check fails", i)
+		}
+	}
+}
+
+func TestMachineID(t *testing.T) {
+	netDir := machineIDDir(t, []testInterface{
+		{name: "lo", address: "00:00:00:00:00:00"},
+		{name: "eth0", address: "0A:1B:2C:3D:4E:5F", physical: true},
+	})
+	defer os.RemoveAll(netDir)
+	uuidFile := writeTempFile(t, "EC2A5A4E-1C6B-9C3E-2B6B-3A5E7C1F9D22\n")
+	defer os.Remove(uuidFile)
+	id, err := MachineIDCheck{productUUIDFile: uuidFile, netDir: netDir}.MachineID()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id.ProductUUID != "EC2A5A4E-1C6B-9C3E-2B6B-3A5E7C1F9D22" {
+		t.Errorf("unexpected product UUID %q", id.ProductUUID)
+	}
+	if len(id.MACAddresses) != 1 || id.MACAddresses["eth0"] != "0a:1b:2c:3d:4e:5f" {
+		t.Errorf("unexpected MAC addresses %v", id.MACAddresses)
+	}
+}
+
+func TestMachineIDMissingProductUUID(t *testing.T) {
+	netDir := machineIDDir(t, []testInterface{
+		{name: "eth0", address: "0a:1b:2c:3d:4e:5f", physical: true},
+	})
+	defer os.RemoveAll(netDir)
+	c := MachineIDCheck{productUUIDFile: filepath.Join(netDir, "product_uuid"), netDir: netDir}
+	id, err := c.MachineID()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id.ProductUUID != "" {
+		t.Errorf("expected an empty product UUID, got %q", id.ProductUUID)
+	}
+	if ok, err := c.Check(); !ok || err != nil {
+		t.Errorf("expected the check to pass, but got %v, %v", ok, err)
+	}
+}
+
+func TestMachineIDMissingProductUUIDSharedMAC(t *testing.T) {
+	netDir := machineIDDir(t, []testInterface{
+		{name: "eth0", address: "0a:1b:2c:3d:4e:5f", physical: true},
+		{name: "eth1", address: "0a:1b:2c:3d:4e:5f", physical: true},
+	})
+	defer os.RemoveAll(netDir)
+	c := MachineIDCheck{productUUIDFile: filepath.Join(netDir, "product_uuid"), netDir: netDir}
+	if ok, err := c.Check(); ok || err == nil {
+		t.Errorf("expected the check to fail with an error, but got %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,146 @@
+package check
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const procMountsFile = "/proc/mounts"
+
+// PathMountCheck verifies that a path resides on the expected mount point,
+// and optionally, on the expected device.
+type PathMountCheck struct {
+	// Path that must reside on the mount. It does not have to exist yet.
+	Path string
+	// MountPoint where the path is expected to be mounted
+	MountPoint string
+	// Device that is expected to back the mount point. Optional.
+	Device string
+	// file to read the mounts from. Defaults to /proc/mounts
+	mountsFile string
+}
+
+type mount struct {
+	device     string
+	mountPoint string
+}
+
+// Check returns true if the path resides on the expected mount. Otherwise,
+// returns false and an error that contains the mount backing the path.
+func (c PathMountCheck) Check() (bool, error) {
+	file := c.mountsFile
+	if file == "" {
+		file = procMountsFile
+	}
+	mounts, err := readMounts(file)
+	if err != nil {
+		return false, err
+	}
+	path, err := resolvePath(c.Path)
+	if err != nil {
+		return false, err
+	}
+	m, ok := findMount(path, mounts)
+	if !ok {
+		return false, fmt.Errorf("could not find the mount backing %q", c.Path)
+	}
+	if m.mountPoint != filepath.Clean(c.MountPoint) {
+		return false, fmt.Errorf("%q is on mount %q (device %q), not on %q", c.Path, m.mountPoint, m.device, c.MountPoint)
+	}
+	if c.Device != "" && m.device != c.Device {
+		return false, fmt.Errorf("%q is on mount %q backed by device %q, not by %q", c.Path, m.mountPoint, m.device, c.Device)
+	}
+	return true, nil
+}
+
+// resolves symlinks of the closest existing ancestor of the path, as the path
+// itself might not exist yet
+func resolvePath(path string) (string, error) {
+	path = filepath.Clean(path)
+	var rest []string
+	for {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("error resolving path %q: %v", path, err)
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", fmt.Errorf("error resolving path %q: %v", path, err)
+		}
+		rest = append([]string{filepath.Base(path)}, rest...)
+		path = parent
+	}
+}
+
+func readMounts(file string) ([]mount, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading mounts from %q: %v", file, err)
+	}
+	defer f.Close()
+	mounts := []mount{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		mounts = append(mounts, mount{device: unescapeMountField(fields[0]), mountPoint: unescapeMountField(fields[1])})
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("error reading mounts from %q: %v", file, err)
+	}
+	return mounts, nil
+}
+
+// findMount returns the mount that backs the path, which is the one with the
+// longest mount point that contains the path. When a mount point has been
+// mounted over, the last mount listed wins.
+func findMount(path string, mounts []mount) (mount, bool) {
+	var found mount
+	var ok bool
+	for _, m := range mounts {
+		if !pathHasPrefix(path, m.mountPoint) {
+			continue
+		}
+		if !ok || len(m.mountPoint) >= len(found.mountPoint) {
+			found = m
+			ok = true
+		}
+	}
+	return found, ok
+}
+
+func pathHasPrefix(path, prefix string) bool {
+	if prefix == "/" || path == prefix {
+		return true
+	}
+	return strings.HasPrefix(path, prefix+"/")
+}
+
+// the kernel escapes spaces, tabs, newlines and backslashes in /proc/mounts
+// using octal sequences, e.g. "\040" for a space
+func unescapeMountField(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if n, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package check
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+const testMounts = `sysfs /sys sysfs rw,nosuid,nodev,noexec,relatime 0 0
+/dev/xvda1 / ext4 rw,relatime,discard,data=ordered 0 0
+/dev/xvdb /var/lib/etcd ext4 rw,relatime,data=ordered 0 0
+/dev/xvdc /mnt/with\040space ext4 rw,relatime,data=ordered 0 0
+`
+
+func TestFindMount(t *testing.T) {
+	f, err := ioutil.TempFile("", "mounts")
+	if err != nil {
+		t.Fatalf("error creating temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString(testMounts)
+	f.Close()
+	mounts, err := readMounts(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error reading mounts: %v", err)
+	}
+	tests := []struct {
+		path               string
+		expectedMountPoint string
+		expectedDevice     string
+	}{
+		{
+			path:               "/var/lib/etcd",
+			expectedMountPoint: "/var/lib/etcd",
+			expectedDevice:     "/dev/xvdb",
+		},
+		{
+			path:               "/var/lib/etcd/member/snap",
+			expectedMountPoint: "/var/lib/etcd",
+			expectedDevice:     "/dev/xvdb",
+		},
+		{
+			path:               "/var/lib/etcd2",
+			expectedMountPoint: "/",
+			expectedDevice:     "/dev/xvda1",
+		},
+		{
+			path:               "/mnt/with space/data",
+			expectedMountPoint: "/mnt/with space",
+			expectedDevice:     "/dev/xvdc",
+		},
+	}
+	for _, test := range tests {
+		m, ok := findMount(test.path, mounts)
+		if !ok {
+			t.Errorf("did not find mount for %q", test.path)
+			continue
+		}
+		if m.mountPoint != test.expectedMountPoint {
+			t.Errorf("expected mount point %q for %q, but got %q", test.expectedMountPoint, test.path, m.mountPoint)
+		}
+		if m.device != test.expectedDevice {
+			t.Errorf("expected device %q for %q, but got %q", test.expectedDevice, test.path, m.device)
+		}
+	}
+}
+
+func TestPathMountCheckRootFilesystem(t *testing.T) {
+	c := PathMountCheck{
+		Path:       "/",
+		MountPoint: "/",
+	}
+	ok, err := c.Check()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected check to pass for the root filesystem")
+	}
+}
+
+func TestPathMountCheckWrongMount(t *testing.T) {
+	c := PathMountCheck{
+		Path:       "/",
+		MountPoint: "/some/mount/that/does/not/exist",
+	}
+	ok, err := c.Check()
+	if err == nil {
+		t.Errorf("expected an error, but didn't get one")
+	}
+	if ok {
+		t.Errorf("expected check to fail for a mount point that does not exist")
+	}
+}
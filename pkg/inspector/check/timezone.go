@@ -0,0 +1,69 @@
+package check
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TimeZoneCheck verifies that the time zone configured on the node is the
+// expected time zone
+type TimeZoneCheck struct {
+	TimeZone string
+	// the root of the filesystem to read the configuration from. Defaults to /
+	root string
+}
+
+// Check returns true if the configured time zone is the expected time zone.
+// Otherwise, returns false and an error that contains the configured time zone.
+func (c TimeZoneCheck) Check() (bool, error) {
+	root := c.root
+	if root == "" {
+		root = "/"
+	}
+	tz, err := configuredTimeZone(root)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine the configured time zone: %v", err)
+	}
+	if normalizeTimeZone(tz) != normalizeTimeZone(c.TimeZone) {
+		return false, fmt.Errorf("the time zone is %s, but %s is required. Set it with 'timedatectl set-timezone %s'", tz, c.TimeZone, c.TimeZone)
+	}
+	return true, nil
+}
+
+// configuredTimeZone returns the name of the zone that /etc/localtime points
+// to, falling back to /etc/timezone. When neither exists, the system uses UTC.
+func configuredTimeZone(root string) (string, error) {
+	localtime := filepath.Join(root, "etc/localtime")
+	if target, err := os.Readlink(localtime); err == nil {
+		if i := strings.Index(target, "zoneinfo/"); i >= 0 {
+			return target[i+len("zoneinfo/"):], nil
+		}
+	}
+	b, err := ioutil.ReadFile(filepath.Join(root, "etc/timezone"))
+	if err == nil {
+		return strings.TrimSpace(string(b)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	if _, err := os.Lstat(localtime); os.IsNotExist(err) {
+		return "UTC", nil
+	}
+	return "", fmt.Errorf("%s is not a link to a zone in the time zone database, and /etc/timezone does not exist", localtime)
+}
+
+// zones that are aliases of UTC in the time zone database
+var utcAliases = []string{"UTC", "UCT", "Universal", "Zulu", "GMT", "GMT0", "Greenwich"}
+
+func normalizeTimeZone(tz string) string {
+	tz = strings.TrimPrefix(tz, "Etc/")
+	for _, a := range utcAliases {
+		if strings.EqualFold(tz, a) {
+			return "UTC"
+		}
+	}
+	return tz
+}
@@ -0,0 +1,55 @@
+package check
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTimeZoneCheck(t *testing.T) {
+	tests := []struct {
+		localtime string
+		timezone  string
+		expected  string
+		ok        bool
+		reported  string
+	}{
+		{expected: "UTC", ok: true},
+		{localtime: "/usr/share/zoneinfo/UTC", expected: "UTC", ok: true},
+		{localtime: "../usr/share/zoneinfo/Etc/UTC", expected: "UTC", ok: true},
+		{localtime: "/usr/share/zoneinfo/America/New_York", expected: "America/New_York", ok: true},
+		{localtime: "/usr/share/zoneinfo/America/New_York", expected: "UTC", ok: false, reported: "America/New_York"},
+		{timezone: "Etc/UTC\n", expected: "UTC", ok: true},
+		{timezone: "Europe/Berlin\n", expected: "UTC", ok: false, reported: "Europe/Berlin"},
+	}
+	for i, test := range tests {
+		root, err := ioutil.TempDir("", "timezone")
+		if err != nil {
+			t.Fatalf("error creating temp dir: %v", err)
+		}
+		defer os.RemoveAll(root)
+		if err := os.Mkdir(filepath.Join(root, "etc"), 0755); err != nil {
+			t.Fatalf("error creating etc dir: %v", err)
+		}
+		if test.localtime != "" {
+			if err := os.Symlink(test.localtime, filepath.Join(root, "etc/localtime")); err != nil {
+				t.Fatalf("error creating localtime link: %v", err)
+			}
+		}
+		if test.timezone != "" {
+			if err := ioutil.WriteFile(filepath.Join(root, "etc/timezone"), []byte(test.timezone), 0644); err != nil {
+				t.Fatalf("error writing timezone file: %v", err)
+			}
+		}
+		c := TimeZoneCheck{TimeZone: test.expected, root: root}
+		ok, err := c.Check()
+		if ok != test.ok {
+			t.Errorf("test %d: expected %t, but got %t: %v", i, test.ok, ok, err)
+		}
+		if !ok && !strings.Contains(err.Error(), test.reported) {
+			t.Errorf("test %d: expected the error to report %q, but got %v", i, test.reported, err)
+		}
+	}
+}
@@ -16,7 +16,10 @@ type Client struct {
 	TargetNode string
 	// TargetNodeRole is the role of the node we are inspecting
 	TargetNodeFacts []string
-	engine          *rule.Engine
+	// Sanitizer is optional. When set, it redacts sensitive data from the
+	// results of the server and of the rules that run on the client.
+	Sanitizer *rule.Sanitizer
+	engine    *rule.Engine
 }
 
 // NewClient returns an inspector client for running checks against remote nodes.
@@ -83,7 +86,7 @@ func (c Client) ExecuteRules(rules []rule.Rule) ([]rule.Result, error) {
 		return nil, fmt.Errorf("GET request to %q failed. You might have to restart the inspector server. Error was: %v", endpoint, err)
 	}
 
-	return results, nil
+	return c.Sanitizer.SanitizeResults(results), nil
 }
 
 func getServerSideRules(rules []rule.Rule) []rule.Rule {
@@ -7,16 +7,20 @@ import (
 	"strings"
 
 	"github.com/apprenda/kismatic/pkg/inspector"
+	"github.com/apprenda/kismatic/pkg/inspector/rule"
 	"github.com/spf13/cobra"
 )
 
 type clientOpts struct {
 	outputType          string
+	colorMode           string
+	categories          []string
 	nodeRoles           string
 	rulesFile           string
 	targetNode          string
 	useUpgradeDefaults  bool
 	additionalVariables map[string]string
+	redactPatterns      []string
 }
 
 var clientExample = `# Run the inspector against an etcd node
@@ -54,10 +58,13 @@ func NewCmdClient(out io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&opts.outputType, "output", "o", "table", "set the result output type. Options are 'json', 'table'")
+	cmd.Flags().StringSliceVar(&opts.categories, "categories", []string{}, "comma-separated list of rule categories to run, such as 'networking'. If blank, all rules are run")
+	cmd.Flags().StringVar(&opts.colorMode, "color", "auto", "whether to color the table output. Options are 'auto', 'always', 'never'")
 	cmd.Flags().StringVar(&opts.nodeRoles, "node-roles", "", "comma-separated list of the node's roles. Valid roles are 'etcd', 'master', 'worker'")
 	cmd.Flags().StringVarP(&opts.rulesFile, "file", "f", "", "the path to an inspector rules file. If blank, the inspector uses the default rules")
 	cmd.Flags().BoolVarP(&opts.useUpgradeDefaults, "upgrade", "u", false, "use defaults for upgrade, rather than install")
 	cmd.Flags().StringSliceVar(&additionalVars, "additional-vars", []string{}, "key=value pairs separated by ',' to template ruleset")
+	cmd.Flags().StringArrayVar(&opts.redactPatterns, "redact", []string{}, "regular expression for sensitive data, such as tokens, that is redacted from the result messages. Can be repeated")
 	return cmd
 }
 
@@ -65,6 +72,9 @@ func runClient(out io.Writer, opts clientOpts) error {
 	if err := validateOutputType(opts.outputType); err != nil {
 		return err
 	}
+	if err := validateColorMode(opts.colorMode); err != nil {
+		return err
+	}
 	if opts.nodeRoles == "" {
 		return fmt.Errorf("--node-roles is required")
 	}
@@ -76,16 +86,22 @@ func runClient(out io.Writer, opts clientOpts) error {
 	if err != nil {
 		return fmt.Errorf("error creating inspector client: %v", err)
 	}
+	if c.Sanitizer, err = rule.NewSanitizer(opts.redactPatterns); err != nil {
+		return err
+	}
 	rules, err := getRulesFromFileOrDefault(out, opts.rulesFile, opts.useUpgradeDefaults, opts.additionalVariables)
 	if err != nil {
 		return err
 	}
+	if rules, err = rule.FilterByCategory(rules, opts.categories); err != nil {
+		return err
+	}
 
 	results, err := c.ExecuteRules(rules)
 	if err != nil {
 		return fmt.Errorf("error running inspector against remote node: %v", err)
 	}
-	if err := printResults(out, results, opts.outputType); err != nil {
+	if err := printResults(out, results, opts.outputType, opts.colorMode, opts.categories); err != nil {
 		return err
 	}
 	for _, r := range results {
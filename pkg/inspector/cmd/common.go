@@ -35,6 +35,13 @@ func getRulesFromFileOrDefault(out io.Writer, file string, useUpgradeRules bool,
 	return rule.DefaultRules(vars), nil
 }
 
+func validateColorMode(colorMode string) error {
+	if colorMode != "auto" && colorMode != "always" && colorMode != "never" {
+		return fmt.Errorf("color mode %q not supported", colorMode)
+	}
+	return nil
+}
+
 func validateOutputType(outputType string) error {
 	if outputType != "json" && outputType != "table" {
 		return fmt.Errorf("output type %q not supported", outputType)
@@ -13,6 +13,8 @@ import (
 
 type localOpts struct {
 	outputType                  string
+	colorMode                   string
+	categories                  []string
 	nodeRoles                   string
 	rulesFile                   string
 	packageInstallationDisabled bool
@@ -20,6 +22,9 @@ type localOpts struct {
 	disconnectedInstallation    bool
 	useUpgradeDefaults          bool
 	additionalVariables         map[string]string
+	reportURL                   string
+	dryRun                      bool
+	redactPatterns              []string
 }
 
 var localExample = `# Run with a custom rules file
@@ -47,6 +52,8 @@ func NewCmdLocal(out io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&opts.outputType, "output", "o", "table", "set the result output type. Options are 'json', 'table'")
+	cmd.Flags().StringSliceVar(&opts.categories, "categories", []string{}, "comma-separated list of rule categories to run, such as 'networking'. If blank, all rules are run")
+	cmd.Flags().StringVar(&opts.colorMode, "color", "auto", "whether to color the table output. Options are 'auto', 'always', 'never'")
 	cmd.Flags().StringVar(&opts.nodeRoles, "node-roles", "", "comma-separated list of the node's roles. Valid roles are 'etcd', 'master', 'worker'")
 	cmd.Flags().StringVarP(&opts.rulesFile, "file", "f", "", "the path to an inspector rules file. If blank, the inspector uses the default rules")
 	cmd.Flags().BoolVar(&opts.packageInstallationDisabled, "pkg-installation-disabled", false, "when true, the inspector will ensure that the necessary packages are installed on the node")
@@ -54,6 +61,9 @@ func NewCmdLocal(out io.Writer) *cobra.Command {
 	cmd.Flags().BoolVar(&opts.disconnectedInstallation, "disconnected-installation", false, "when true will check for the required packages needed during a disconnected install")
 	cmd.Flags().BoolVarP(&opts.useUpgradeDefaults, "upgrade", "u", false, "use defaults for upgrade, rather than install")
 	cmd.Flags().StringSliceVar(&additionalVars, "additional-vars", []string{}, "provide a key=value list to template ruleset")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "list the rules that would run on the node without running their checks")
+	cmd.Flags().StringVar(&opts.reportURL, "report-url", "", "URL of a collector where the results will be sent. If blank, results are not reported")
+	cmd.Flags().StringArrayVar(&opts.redactPatterns, "redact", []string{}, "regular expression for sensitive data, such as tokens, that is redacted from the result messages. Can be repeated")
 	return cmd
 }
 
@@ -68,11 +78,17 @@ func runLocal(out io.Writer, opts localOpts) error {
 	if err = validateOutputType(opts.outputType); err != nil {
 		return err
 	}
+	if err = validateColorMode(opts.colorMode); err != nil {
+		return err
+	}
 	// Gather rules
 	rules, err := getRulesFromFileOrDefault(out, opts.rulesFile, opts.useUpgradeDefaults, opts.additionalVariables)
 	if err != nil {
 		return err
 	}
+	if rules, err = rule.FilterByCategory(rules, opts.categories); err != nil {
+		return err
+	}
 	// Set up engine dependencies
 	distro, err := check.DetectDistro()
 	if err != nil {
@@ -83,6 +99,11 @@ func runLocal(out io.Writer, opts localOpts) error {
 		return err
 	}
 
+	sanitizer, err := rule.NewSanitizer(opts.redactPatterns)
+	if err != nil {
+		return err
+	}
+
 	// Create rule engine
 	e := rule.Engine{
 		RuleCheckMapper: rule.DefaultCheckMapper{
@@ -91,13 +112,24 @@ func runLocal(out io.Writer, opts localOpts) error {
 			DockerInstallationDisabled:  opts.dockerInstallationDisabled,
 			DisconnectedInstallation:    opts.disconnectedInstallation,
 		},
+		Sanitizer: sanitizer,
+	}
+	if opts.reportURL != "" {
+		e.Reporter = rule.HTTPResultReporter{URL: opts.reportURL, Metadata: rule.NodeMetadata()}
 	}
 	labels := append(roles, string(distro))
+	if initSystem, err := check.DetectInitSystem(); err == nil {
+		labels = append(labels, string(initSystem))
+	}
+	if opts.dryRun {
+		return printRulePlans(out, e.PlanRules(rules, labels), opts.outputType)
+	}
 	results, err := e.ExecuteRules(rules, labels)
+	defer e.WaitForReports()
 	if err != nil {
 		return fmt.Errorf("error running local rules: %v", err)
 	}
-	if err := printResults(out, results, opts.outputType); err != nil {
+	if err := printResults(out, results, opts.outputType, opts.colorMode, opts.categories); err != nil {
 		return fmt.Errorf("error printing results: %v", err)
 	}
 	for _, r := range results {
@@ -4,36 +4,86 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/apprenda/kismatic/pkg/inspector/rule"
+	isatty "github.com/mattn/go-isatty"
 )
 
-func printResults(out io.Writer, results []rule.Result, outputType string) error {
+func printResults(out io.Writer, results []rule.Result, outputType string, colorMode string, categories []string) error {
 	switch outputType {
 	case "json":
-		return printResultsAsJSON(out, results)
+		return printResultsAsJSON(out, results, categories)
 	case "table":
-		return printResultsAsTable(out, results)
+		return rule.WriteTable(out, results, rule.TableOptions{Color: useColor(out, colorMode), Categories: categories})
 	default:
 		return fmt.Errorf("output type %q not supported", outputType)
 	}
 }
 
-func printResultsAsJSON(out io.Writer, results []rule.Result) error {
-	err := json.NewEncoder(out).Encode(results)
+// printResultsAsJSON prints the list of results. When categories were
+// selected, the results are wrapped in an object that includes them.
+func printResultsAsJSON(out io.Writer, results []rule.Result, categories []string) error {
+	var v interface{} = results
+	if len(categories) > 0 {
+		v = struct {
+			Categories []string
+			Results    []rule.Result
+		}{categories, results}
+	}
+	err := json.NewEncoder(out).Encode(v)
 	if err != nil {
 		return fmt.Errorf("error marshaling results as JSON: %v", err)
 	}
 	return nil
 }
 
-func printResultsAsTable(out io.Writer, results []rule.Result) error {
-	w := tabwriter.NewWriter(out, 1, 8, 4, '\t', 0)
-	fmt.Fprintf(w, "CHECK\tSUCCESS\tMSG\n")
-	for _, r := range results {
-		fmt.Fprintf(w, "%s\t%t\t%v\n", r.Name, r.Success, r.Error)
+// useColor returns true when the output should be colored. In "auto" mode,
+// colors are only used when writing to a terminal.
+func useColor(out io.Writer, colorMode string) bool {
+	switch colorMode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	type fd interface {
+		Fd() uintptr
+	}
+	f, ok := out.(fd)
+	return ok && isatty.IsTerminal(f.Fd())
+}
+
+func printRulePlans(out io.Writer, plans []rule.RulePlan, outputType string) error {
+	if outputType == "json" {
+		return printRulePlansAsJSON(out, plans)
+	}
+	w := tabwriter.NewWriter(out, 1, 8, 4, ' ', 0)
+	fmt.Fprintf(w, "CHECK\tRUN\tEXCLUDED BY\n")
+	for _, p := range plans {
+		excludedBy := "-"
+		if !p.Execute {
+			excludedBy = "none of " + strings.Join(p.UnmetCondition, ", ")
+		}
+		fmt.Fprintf(w, "%s\t%t\t%s\n", p.Rule.Name(), p.Execute, excludedBy)
+	}
+	return w.Flush()
+}
+
+// the rules are listed by name, as their fields differ for every kind
+func printRulePlansAsJSON(out io.Writer, plans []rule.RulePlan) error {
+	type rulePlan struct {
+		Name           string
+		Execute        bool
+		UnmetCondition []string `json:",omitempty"`
+	}
+	list := make([]rulePlan, 0, len(plans))
+	for _, p := range plans {
+		list = append(list, rulePlan{Name: p.Rule.Name(), Execute: p.Execute, UnmetCondition: p.UnmetCondition})
+	}
+	if err := json.NewEncoder(out).Encode(list); err != nil {
+		return fmt.Errorf("error marshaling rule plans as JSON: %v", err)
 	}
-	w.Flush()
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/apprenda/kismatic/pkg/inspector"
+	"github.com/apprenda/kismatic/pkg/inspector/rule"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +23,7 @@ type serverOpts struct {
 	packageInstallationDisabled bool
 	dockerInstallationDisabled  bool
 	disconnectedInstallation    bool
+	redactPatterns              []string
 }
 
 // NewCmdServer returns the "server" command
@@ -41,6 +43,7 @@ func NewCmdServer(out io.Writer) *cobra.Command {
 	cmd.Flags().BoolVar(&opts.packageInstallationDisabled, "pkg-installation-disabled", false, "when true, the inspector will ensure that the necessary packages are installed on the node")
 	cmd.Flags().BoolVar(&opts.dockerInstallationDisabled, "docker-installation-disabled", false, "when true, the inspector will check for docker packages to be installed")
 	cmd.Flags().BoolVar(&opts.disconnectedInstallation, "disconnected-installation", false, "when true will check for the required packages needed during a disconnected install")
+	cmd.Flags().StringArrayVar(&opts.redactPatterns, "redact", []string{}, "regular expression for sensitive data, such as tokens, that is redacted from the result messages. Can be repeated")
 	return cmd
 }
 
@@ -59,6 +62,9 @@ func runServer(out io.Writer, opts serverOpts) error {
 	if err != nil {
 		return fmt.Errorf("error starting up inspector server: %v", err)
 	}
+	if s.Sanitizer, err = rule.NewSanitizer(opts.redactPatterns); err != nil {
+		return err
+	}
 	fmt.Fprintf(out, "Inspector is listening on port %d\n", opts.port)
 	fmt.Fprintf(out, "Node roles: %s\n", opts.nodeRoles)
 	fmt.Fprintf(out, "Package installation disabled: %v\n", opts.packageInstallationDisabled)
@@ -0,0 +1,84 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+)
+
+// the category of the rules that do not declare their own
+var defaultCategories = map[string]string{
+	"packagedependency":             "packages",
+	"packagenotinstalled":           "packages",
+	"noconflictinginstall":          "packages",
+	"tcpportavailable":              "networking",
+	"tcpportaccessible":             "networking",
+	"httpreachable":                 "networking",
+	"domainnotexternallyresolvable": "networking",
+	"conntrackconfigured":           "networking",
+	"kernelmoduleloaded":            "networking",
+	"freespace":                     "resources",
+	"pathonmount":                   "resources",
+	"inotifywatchlimit":             "resources",
+	"executableinpath":              "system",
+	"filecontentmatches":            "system",
+	"dockerinpath":                  "system",
+	"python2version":                "system",
+	"interpreterpresent":            "system",
+	"initsystemsupported":           "system",
+	"uniquemachineid":               "system",
+	"timezonematches":               "system",
+}
+
+// Categories returns the categories of the rule
+func Categories(r Rule) []string {
+	meta := r.GetRuleMeta()
+	if len(meta.Categories) > 0 {
+		return meta.Categories
+	}
+	if c, ok := defaultCategories[strings.ToLower(meta.Kind)]; ok {
+		return []string{c}
+	}
+	return nil
+}
+
+// FilterByCategory returns the rules that belong to at least one of the
+// categories. All rules are returned when no categories are given. An error
+// is returned if a category is neither a default category nor declared by
+// any of the rules.
+func FilterByCategory(rules []Rule, categories []string) ([]Rule, error) {
+	if len(categories) == 0 {
+		return rules, nil
+	}
+	known := map[string]bool{}
+	for _, c := range defaultCategories {
+		known[c] = true
+	}
+	for _, r := range rules {
+		for _, c := range Categories(r) {
+			known[strings.ToLower(c)] = true
+		}
+	}
+	for _, c := range categories {
+		if !known[strings.ToLower(c)] {
+			return nil, fmt.Errorf("unknown rule category %q", c)
+		}
+	}
+	filtered := []Rule{}
+	for _, r := range rules {
+		if inAnyCategory(r, categories) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+func inAnyCategory(r Rule, categories []string) bool {
+	for _, rc := range Categories(r) {
+		for _, c := range categories {
+			if strings.EqualFold(rc, c) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package rule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCategories(t *testing.T) {
+	tests := []struct {
+		rule     Rule
+		expected []string
+	}{
+		{rule: TCPPortAvailable{Meta: Meta{Kind: "tcpportavailable"}}, expected: []string{"networking"}},
+		{rule: FreeSpace{Meta: Meta{Kind: "FreeSpace"}}, expected: []string{"resources"}},
+		{rule: FileContentMatches{Meta: Meta{Kind: "FileContentMatches"}}, expected: []string{"system"}},
+		{rule: FreeSpace{Meta: Meta{Kind: "freespace", Categories: []string{"storage"}}}, expected: []string{"storage"}},
+		{rule: fakeRule{Meta: Meta{Kind: "unknown"}}},
+	}
+	for i, test := range tests {
+		if got := Categories(test.rule); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("test %d: expected categories %v, got %v", i, test.expected, got)
+		}
+	}
+}
+
+func TestFilterByCategory(t *testing.T) {
+	port := TCPPortAvailable{Meta: Meta{Kind: "tcpportavailable"}, Port: 80}
+	space := FreeSpace{Meta: Meta{Kind: "freespace"}, Path: "/"}
+	custom := ExecutableInPath{Meta: Meta{Kind: "executableinpath", Categories: []string{"security", "networking"}}, Executable: "iptables"}
+	rules := []Rule{port, space, custom}
+	tests := []struct {
+		categories []string
+		expected   []Rule
+		err        bool
+	}{
+		{categories: nil, expected: rules},
+		{categories: []string{"networking"}, expected: []Rule{port, custom}},
+		{categories: []string{"Resources", "security"}, expected: []Rule{space, custom}},
+		{categories: []string{"packages"}, expected: []Rule{}},
+		{categories: []string{"networking", "bogus"}, err: true},
+	}
+	for i, test := range tests {
+		got, err := FilterByCategory(rules, test.categories)
+		if test.err != (err != nil) {
+			t.Errorf("test %d: expected error %t, got %v", i, test.err, err)
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, test.expected, got)
+		}
+	}
+}
+
+func TestUnmarshalRuleCategories(t *testing.T) {
+	rules, err := UnmarshalRulesYAML([]byte(`---
+- kind: FreeSpace
+  categories: ["storage"]
+  path: /
+  minimumBytes: "1000"
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := Categories(rules[0]); !reflect.DeepEqual(got, []string{"storage"}) {
+		t.Errorf("expected the declared categories, got %v", got)
+	}
+}
@@ -2,6 +2,7 @@ package rule
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/apprenda/kismatic/pkg/inspector/check"
@@ -13,6 +14,63 @@ type CheckMapper interface {
 	GetCheckForRule(Rule) (check.Check, error)
 }
 
+// CheckMapperFunc is an adapter that allows the use of an ordinary
+// function as a CheckMapper.
+type CheckMapperFunc func(Rule) (check.Check, error)
+
+// GetCheckForRule calls f(rule)
+func (f CheckMapperFunc) GetCheckForRule(rule Rule) (check.Check, error) {
+	return f(rule)
+}
+
+// The OverrideCheckMapper maps rules to checks using a set of overrides,
+// and delegates all other rules to another mapper. This allows the checks of
+// selected rules to be replaced without having to reimplement the entire mapping.
+//
+// Precedence: if an override is registered for the rule's kind, the
+// override is used and the delegate is never consulted for that rule.
+// Otherwise, the rule is handed to the delegate, which is typically
+// a DefaultCheckMapper.
+type OverrideCheckMapper struct {
+	delegate  CheckMapper
+	overrides map[string]CheckMapper
+}
+
+// NewOverrideCheckMapper returns a mapper that uses the overrides, keyed by
+// rule kind (e.g. "PackageDependency"), and the delegate for all other rules.
+// Kinds are matched case-insensitively, so an error is returned if two
+// overrides are registered for the same kind.
+func NewOverrideCheckMapper(delegate CheckMapper, overrides map[string]CheckMapper) (*OverrideCheckMapper, error) {
+	m := &OverrideCheckMapper{
+		delegate:  delegate,
+		overrides: make(map[string]CheckMapper, len(overrides)),
+	}
+	for k, mapper := range overrides {
+		kind := normalizeKind(k)
+		if _, ok := m.overrides[kind]; ok {
+			return nil, fmt.Errorf("more than one override was registered for rule kind %q", kind)
+		}
+		m.overrides[kind] = mapper
+	}
+	return m, nil
+}
+
+// GetCheckForRule returns the check for the given rule, using the override
+// for the rule's kind if there is one.
+func (m OverrideCheckMapper) GetCheckForRule(rule Rule) (check.Check, error) {
+	if mapper, ok := m.overrides[normalizeKind(rule.GetRuleMeta().Kind)]; ok {
+		return mapper.GetCheckForRule(rule)
+	}
+	if m.delegate == nil {
+		return nil, fmt.Errorf("Rule of kind %q is not overridden, and no delegate mapper was provided", rule.GetRuleMeta().Kind)
+	}
+	return m.delegate.GetCheckForRule(rule)
+}
+
+func normalizeKind(kind string) string {
+	return strings.ToLower(strings.TrimSpace(kind))
+}
+
 // The DefaultCheckMapper contains the mappings for all
 // supported rules and checks.
 type DefaultCheckMapper struct {
@@ -60,6 +118,34 @@ func (m DefaultCheckMapper) GetCheckForRule(rule Rule) (check.Check, error) {
 	case FreeSpace:
 		bytes, _ := r.minimumBytesAsUint64() // ignore this err, as we have already validated the rule
 		c = &check.FreeSpaceCheck{Path: r.Path, MinimumBytes: bytes}
+	case PathOnMount:
+		c = &check.PathMountCheck{Path: r.Path, MountPoint: r.MountPoint, Device: r.Device}
+	case InotifyWatchLimit:
+		watches, _ := r.minimumWatchesAsUint64() // ignore this err, as we have already validated the rule
+		c = &check.InotifyCheck{MinimumWatches: watches}
+	case KernelModuleLoaded:
+		c = &check.KernelModuleCheck{Module: r.Module}
+	case HTTPReachable:
+		var timeout time.Duration
+		if r.Timeout != "" {
+			timeout, _ = time.ParseDuration(r.Timeout) // ignore this err, as we have already validated the rule
+		}
+		c = &check.HTTPReachableCheck{URL: r.URL, Timeout: timeout, CertsDir: r.CertsDir}
+	case NoConflictingInstall:
+		c = &check.ConflictingInstallCheck{}
+	case DomainNotExternallyResolvable:
+		c = &check.DomainLeakCheck{Domain: r.Domain, Resolver: r.Resolver}
+	case InterpreterPresent:
+		c = &check.InterpreterCheck{Name: r.Interpreter, MinimumVersion: r.MinimumVersion}
+	case InitSystemSupported:
+		c = &check.InitSystemCheck{SupportedInitSystems: r.SupportedInitSystems}
+	case UniqueMachineID:
+		c = &check.MachineIDCheck{}
+	case ConntrackConfigured:
+		max, _ := r.minimumMaxAsUint64() // ignore this err, as we have already validated the rule
+		c = &check.ConntrackCheck{MinimumMax: max}
+	case TimeZoneMatches:
+		c = &check.TimeZoneCheck{TimeZone: r.TimeZone}
 	}
 	return c, nil
 }
@@ -0,0 +1,75 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/apprenda/kismatic/pkg/inspector/check"
+)
+
+func TestOverrideCheckMapper(t *testing.T) {
+	overridden := fakeCheck{ok: true}
+	delegated := fakeCheck{ok: false}
+	m, err := NewOverrideCheckMapper(fakeRuleCheckMapper{check: delegated}, map[string]CheckMapper{
+		"PackageDependency": CheckMapperFunc(func(Rule) (check.Check, error) {
+			return overridden, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		rule     Rule
+		expected check.Check
+	}{
+		{
+			rule:     PackageDependency{Meta: Meta{Kind: "packagedependency"}, PackageName: "foo"},
+			expected: overridden,
+		},
+		{
+			rule:     ExecutableInPath{Meta: Meta{Kind: "executableinpath"}, Executable: "foo"},
+			expected: delegated,
+		},
+	}
+	for i, test := range tests {
+		c, err := m.GetCheckForRule(test.rule)
+		if err != nil {
+			t.Errorf("test #%d: unexpected error: %v", i, err)
+		}
+		if c != test.expected {
+			t.Errorf("test #%d: expected check %v, but got %v", i, test.expected, c)
+		}
+	}
+}
+
+func TestOverrideCheckMapperNoDelegate(t *testing.T) {
+	m, err := NewOverrideCheckMapper(nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := m.GetCheckForRule(DockerInPath{Meta: Meta{Kind: "dockerinpath"}}); err == nil {
+		t.Errorf("expected an error, but didn't get one")
+	}
+}
+
+func TestOverrideCheckMapperOverrideError(t *testing.T) {
+	m, err := NewOverrideCheckMapper(fakeRuleCheckMapper{check: fakeCheck{ok: true}}, map[string]CheckMapper{
+		"dockerinpath": fakeRuleCheckMapper{err: errors.New("override error")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := m.GetCheckForRule(DockerInPath{Meta: Meta{Kind: "dockerinpath"}}); err == nil {
+		t.Errorf("expected an error, but didn't get one")
+	}
+}
+
+func TestOverrideCheckMapperDuplicateKinds(t *testing.T) {
+	_, err := NewOverrideCheckMapper(nil, map[string]CheckMapper{
+		"DockerInPath":  fakeRuleCheckMapper{check: fakeCheck{ok: true}},
+		" dockerinpath": fakeRuleCheckMapper{check: fakeCheck{ok: false}},
+	})
+	if err == nil {
+		t.Errorf("expected an error, but didn't get one")
+	}
+}
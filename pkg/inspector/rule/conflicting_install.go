@@ -0,0 +1,19 @@
+package rule
+
+// The NoConflictingInstall rule declares that the node must not have
+// an existing Kubernetes installation, such as kubelet, kubeadm or etcd
+// binaries, running Kubernetes processes, or a populated /etc/kubernetes.
+type NoConflictingInstall struct {
+	Meta
+}
+
+// Name is the name of the rule
+func (n NoConflictingInstall) Name() string {
+	return "No existing Kubernetes installation"
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (n NoConflictingInstall) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (n NoConflictingInstall) Validate() []error { return nil }
@@ -0,0 +1,31 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/apprenda/kismatic/pkg/inspector/check"
+)
+
+func TestNoConflictingInstallRule(t *testing.T) {
+	rules, err := UnmarshalRulesYAML([]byte("- kind: NoConflictingInstall\n  when: [[\"worker\"]]\n"))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling rule: %v", err)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rule, but got %d", len(rules))
+	}
+	r, ok := rules[0].(NoConflictingInstall)
+	if !ok {
+		t.Fatalf("expected a NoConflictingInstall rule, but got %T", rules[0])
+	}
+	if errs := r.Validate(); len(errs) != 0 {
+		t.Errorf("expected 0 errors, but got %v", errs)
+	}
+	c, err := DefaultCheckMapper{}.GetCheckForRule(r)
+	if err != nil {
+		t.Fatalf("unexpected error getting check: %v", err)
+	}
+	if _, ok := c.(*check.ConflictingInstallCheck); !ok {
+		t.Errorf("expected a ConflictingInstallCheck, but got %T", c)
+	}
+}
@@ -0,0 +1,38 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// The ConntrackConfigured rule declares that the nf_conntrack kernel module
+// must be loaded, and that the connection tracking table must hold at
+// least the given number of entries.
+type ConntrackConfigured struct {
+	Meta
+	MinimumMax string
+}
+
+// Name is the name of the rule
+func (c ConntrackConfigured) Name() string {
+	return fmt.Sprintf("nf_conntrack is loaded and net.netfilter.nf_conntrack_max is at least %s", c.MinimumMax)
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (c ConntrackConfigured) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (c ConntrackConfigured) Validate() []error {
+	if c.MinimumMax == "" {
+		return []error{errors.New("MinimumMax cannot be empty")}
+	}
+	if _, err := c.minimumMaxAsUint64(); err != nil {
+		return []error{fmt.Errorf("MinimumMax contains an invalid unsigned integer: %v", err)}
+	}
+	return nil
+}
+
+func (c ConntrackConfigured) minimumMaxAsUint64() (uint64, error) {
+	return strconv.ParseUint(c.MinimumMax, 10, 0)
+}
@@ -0,0 +1,21 @@
+package rule
+
+import "testing"
+
+func TestConntrackConfiguredRuleValidation(t *testing.T) {
+	tests := []struct {
+		minimum string
+		errs    int
+	}{
+		{minimum: "", errs: 1},
+		{minimum: "lots", errs: 1},
+		{minimum: "-1", errs: 1},
+		{minimum: "131072", errs: 0},
+	}
+	for _, test := range tests {
+		r := ConntrackConfigured{MinimumMax: test.minimum}
+		if errs := r.Validate(); len(errs) != test.errs {
+			t.Errorf("minimum %q: expected %d errors, but got %d", test.minimum, test.errs, len(errs))
+		}
+	}
+}
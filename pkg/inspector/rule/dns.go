@@ -0,0 +1,49 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// The DomainNotExternallyResolvable rule declares that the given domain
+// must not be resolvable through the given external DNS resolver. It is
+// used to verify that internal domains do not leak in split-horizon setups.
+type DomainNotExternallyResolvable struct {
+	Meta
+	Domain string
+	// Resolver is the address of the external resolver, with an optional port
+	Resolver string
+}
+
+// Name is the name of the rule
+func (d DomainNotExternallyResolvable) Name() string {
+	return fmt.Sprintf("%s is not resolvable through %s", d.Domain, d.Resolver)
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (d DomainNotExternallyResolvable) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (d DomainNotExternallyResolvable) Validate() []error {
+	errs := []error{}
+	if strings.TrimSpace(d.Domain) == "" {
+		errs = append(errs, errors.New("Domain cannot be empty"))
+	}
+	if d.Resolver == "" {
+		errs = append(errs, errors.New("Resolver cannot be empty"))
+	} else {
+		host := d.Resolver
+		if h, _, err := net.SplitHostPort(d.Resolver); err == nil {
+			host = h
+		}
+		if net.ParseIP(host) == nil {
+			errs = append(errs, fmt.Errorf("Resolver %q is not an IP address", d.Resolver))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package rule
+
+import "testing"
+
+func TestDomainNotExternallyResolvableRuleValidation(t *testing.T) {
+	tests := []struct {
+		rule DomainNotExternallyResolvable
+		errs int
+	}{
+		{rule: DomainNotExternallyResolvable{}, errs: 2},
+		{rule: DomainNotExternallyResolvable{Domain: "cluster.local"}, errs: 1},
+		{rule: DomainNotExternallyResolvable{Resolver: "8.8.8.8"}, errs: 1},
+		{rule: DomainNotExternallyResolvable{Domain: "cluster.local", Resolver: "dns.google"}, errs: 1},
+		{rule: DomainNotExternallyResolvable{Domain: "cluster.local", Resolver: "8.8.8.8"}, errs: 0},
+		{rule: DomainNotExternallyResolvable{Domain: "cluster.local", Resolver: "8.8.8.8:53"}, errs: 0},
+		{rule: DomainNotExternallyResolvable{Domain: "cluster.local", Resolver: "[2001:4860:4860::8888]:53"}, errs: 0},
+	}
+	for i, test := range tests {
+		if errs := test.rule.Validate(); len(errs) != test.errs {
+			t.Errorf("test %d: expected %d errors, but got %d: %v", i, test.errs, len(errs), errs)
+		}
+	}
+}
@@ -53,6 +53,19 @@ type catchAllRule struct {
 	SupportedVersions        []string `yaml:"supportedVersions"`
 	Path                     string   `yaml:"path"`
 	MinimumBytes             string   `yaml:"minimumBytes"`
+	MountPoint               string   `yaml:"mountPoint"`
+	Device                   string   `yaml:"device"`
+	MinimumWatches           string   `yaml:"minimumWatches"`
+	Module                   string   `yaml:"module"`
+	URL                      string   `yaml:"url"`
+	CertsDir                 string   `yaml:"certsDir"`
+	Domain                   string   `yaml:"domain"`
+	Resolver                 string   `yaml:"resolver"`
+	Interpreter              string   `yaml:"interpreter"`
+	MinimumVersion           string   `yaml:"minimumVersion"`
+	SupportedInitSystems     []string `yaml:"supportedInitSystems"`
+	MinimumMax               string   `yaml:"minimumMax"`
+	TimeZone                 string   `yaml:"timeZone"`
 }
 
 // UnmarshalRulesYAML unmarshals the data into a list of rules
@@ -88,8 +101,9 @@ func rulesFromCatchAllRules(catchAllRules []catchAllRule) ([]Rule, error) {
 func buildRule(catchAll catchAllRule) (Rule, error) {
 	kind := strings.ToLower(strings.TrimSpace(catchAll.Kind))
 	meta := Meta{
-		Kind: kind,
-		When: catchAll.When,
+		Kind:       kind,
+		When:       catchAll.When,
+		Categories: catchAll.Categories,
 	}
 	switch kind {
 	default:
@@ -153,6 +167,73 @@ func buildRule(catchAll catchAllRule) (Rule, error) {
 		}
 		r.Meta = meta
 		return r, nil
-
+	case "pathonmount":
+		r := PathOnMount{
+			Path:       catchAll.Path,
+			MountPoint: catchAll.MountPoint,
+			Device:     catchAll.Device,
+		}
+		r.Meta = meta
+		return r, nil
+	case "inotifywatchlimit":
+		r := InotifyWatchLimit{
+			MinimumWatches: catchAll.MinimumWatches,
+		}
+		r.Meta = meta
+		return r, nil
+	case "kernelmoduleloaded":
+		r := KernelModuleLoaded{
+			Module: catchAll.Module,
+		}
+		r.Meta = meta
+		return r, nil
+	case "httpreachable":
+		r := HTTPReachable{
+			URL:      catchAll.URL,
+			Timeout:  catchAll.Timeout,
+			CertsDir: catchAll.CertsDir,
+		}
+		r.Meta = meta
+		return r, nil
+	case "noconflictinginstall":
+		r := NoConflictingInstall{}
+		r.Meta = meta
+		return r, nil
+	case "domainnotexternallyresolvable":
+		r := DomainNotExternallyResolvable{
+			Domain:   catchAll.Domain,
+			Resolver: catchAll.Resolver,
+		}
+		r.Meta = meta
+		return r, nil
+	case "interpreterpresent":
+		r := InterpreterPresent{
+			Interpreter:    catchAll.Interpreter,
+			MinimumVersion: catchAll.MinimumVersion,
+		}
+		r.Meta = meta
+		return r, nil
+	case "initsystemsupported":
+		r := InitSystemSupported{
+			SupportedInitSystems: catchAll.SupportedInitSystems,
+		}
+		r.Meta = meta
+		return r, nil
+	case "uniquemachineid":
+		r := UniqueMachineID{}
+		r.Meta = meta
+		return r, nil
+	case "conntrackconfigured":
+		r := ConntrackConfigured{
+			MinimumMax: catchAll.MinimumMax,
+		}
+		r.Meta = meta
+		return r, nil
+	case "timezonematches":
+		r := TimeZoneMatches{
+			TimeZone: catchAll.TimeZone,
+		}
+		r.Meta = meta
+		return r, nil
 	}
 }
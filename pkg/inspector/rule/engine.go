@@ -1,22 +1,78 @@
 package rule
 
 import (
+	"log"
 	"sync"
 
 	"github.com/apprenda/kismatic/pkg/inspector/check"
 )
 
-// The Engine executes rules and reports the results
+// The Engine executes rules and reports the results.
+//
+// An Engine is safe for concurrent use, as long as the RuleCheckMapper and
+// the Reporter are. Independent inspections on a shared engine should each
+// use their own Run, so that closing the checks of one inspection does not
+// close the checks of another. ExecuteRules and CloseChecks on the engine
+// itself share a single set of closable checks.
 type Engine struct {
 	RuleCheckMapper CheckMapper
-	mu              sync.Mutex
-	closableChecks  []check.ClosableCheck
+	// Reporter is optional. When set, the results of every execution are sent to
+	// the reporter in the background, so that reporting never blocks the inspection.
+	Reporter ResultReporter
+	// Sanitizer is optional. When set, it redacts sensitive data from the
+	// results before they are returned or reported.
+	Sanitizer  *Sanitizer
+	defaultRun Run
+	reports    sync.WaitGroup
+}
+
+// A Run is an independent execution of rules on an Engine. It keeps track of
+// the closable checks that it started, so that they can be closed without
+// affecting other runs on the same engine.
+type Run struct {
+	engine         *Engine
+	mu             sync.Mutex
+	closableChecks []check.ClosableCheck
+}
+
+// NewRun returns a new run that executes rules on the engine
+func (e *Engine) NewRun() *Run {
+	return &Run{engine: e}
 }
 
 // ExecuteRules runs the rules that should be executed according to the facts,
 // and returns a collection of results. The number of results is not guaranteed
 // to equal the number of rules.
 func (e *Engine) ExecuteRules(rules []Rule, facts []string) ([]Result, error) {
+	return e.execute(&e.defaultRun, rules, facts)
+}
+
+// CloseChecks that need to be closed
+func (e *Engine) CloseChecks() error {
+	return e.defaultRun.Close()
+}
+
+// ExecuteRules runs the rules that should be executed according to the facts,
+// as part of the run. The closable checks that succeed remain open until the
+// run is closed.
+func (r *Run) ExecuteRules(rules []Rule, facts []string) ([]Result, error) {
+	return r.engine.execute(r, rules, facts)
+}
+
+// Close the checks that were started by the run
+func (r *Run) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range r.closableChecks {
+		if err := c.Close(); err != nil {
+			// TODO: Figure out what to do with the error here
+		}
+	}
+	r.closableChecks = []check.ClosableCheck{}
+	return nil
+}
+
+func (e *Engine) execute(r *Run, rules []Rule, facts []string) ([]Result, error) {
 	results := []Result{}
 	for _, rule := range rules {
 		if !shouldExecuteRule(rule, facts) {
@@ -39,34 +95,78 @@ func (e *Engine) ExecuteRules(rules []Rule, facts []string) ([]Result, error) {
 		if err != nil {
 			res.Error = err.Error()
 		}
+		if detailed, ok := c.(check.DetailedCheck); ok {
+			res.Details = detailed.Details()
+		}
 
 		// We update the closables as we go to avoid leaking closables
 		// in the event where we have to return an error from within the loop.
 		if closeable, ok := c.(check.ClosableCheck); ok && res.Success {
-			e.mu.Lock()
-			e.closableChecks = append(e.closableChecks, closeable)
-			e.mu.Unlock()
+			r.mu.Lock()
+			r.closableChecks = append(r.closableChecks, closeable)
+			r.mu.Unlock()
 		}
 
-		results = append(results, res)
+		results = append(results, e.Sanitizer.Sanitize(res))
 	}
+	e.report(results, facts)
 	return results, nil
 }
 
-// CloseChecks that need to be closed
-func (e *Engine) CloseChecks() error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-	for _, c := range e.closableChecks {
-		if err := c.Close(); err != nil {
-			// TODO: Figure out what to do with the error here
+func (e *Engine) report(results []Result, facts []string) {
+	if e.Reporter == nil {
+		return
+	}
+	e.reports.Add(1)
+	go func() {
+		defer e.reports.Done()
+		if err := e.Reporter.Report(results, facts); err != nil {
+			log.Printf("error reporting inspection results: %v", err)
 		}
+	}()
+}
+
+// WaitForReports blocks until all pending result reports have been sent.
+// Callers that are about to exit should call this to avoid dropping reports.
+func (e *Engine) WaitForReports() {
+	e.reports.Wait()
+}
+
+// A RulePlan describes whether a rule would be executed given a set of facts
+type RulePlan struct {
+	// Rule that was evaluated
+	Rule Rule
+	// Execute is true when the facts satisfy all the conditions of the rule
+	Execute bool
+	// UnmetCondition is the first condition of the rule that is not satisfied
+	// by the facts. The rule runs only when one of its facts is present.
+	UnmetCondition []string
+}
+
+// PlanRules evaluates the conditions of the rules against the facts, and
+// returns whether each rule would be executed. No checks are run.
+func (e *Engine) PlanRules(rules []Rule, facts []string) []RulePlan {
+	plans := make([]RulePlan, 0, len(rules))
+	for _, rule := range rules {
+		met, unmet := conditionsMet(rule, facts)
+		plans = append(plans, RulePlan{
+			Rule:           rule,
+			Execute:        met,
+			UnmetCondition: unmet,
+		})
 	}
-	e.closableChecks = []check.ClosableCheck{}
-	return nil
+	return plans
 }
 
 func shouldExecuteRule(rule Rule, facts []string) bool {
+	met, _ := conditionsMet(rule, facts)
+	return met
+}
+
+// conditionsMet returns true if all the conditions of the rule are satisfied
+// by the facts. Otherwise, the first condition that is not satisfied is
+// returned. An empty condition is never satisfied.
+func conditionsMet(rule Rule, facts []string) (bool, []string) {
 	// Run if and only if the all the conditions on the rule are
 	// satisfied by the facts
 	for _, whenSlice := range rule.GetRuleMeta().When {
@@ -83,8 +183,8 @@ func shouldExecuteRule(rule Rule, facts []string) bool {
 			}
 		}
 		if !found {
-			return false
+			return false, whenSlice
 		}
 	}
-	return true
+	return true, nil
 }
@@ -2,7 +2,9 @@ package rule
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/apprenda/kismatic/pkg/inspector/check"
@@ -252,3 +254,114 @@ func TestEngineClosableCheckFail(t *testing.T) {
 		t.Errorf("The check failed, and close was called on it")
 	}
 }
+
+type namedCheckMapper map[string]check.Check
+
+func (m namedCheckMapper) GetCheckForRule(r Rule) (check.Check, error) {
+	return m[r.Name()], nil
+}
+
+func TestEngineConcurrentRunsCloseTheirOwnChecks(t *testing.T) {
+	const runCount = 10
+	mapper := namedCheckMapper{}
+	checks := make([]*fakeClosableCheck, runCount)
+	for i := range checks {
+		checks[i] = &fakeClosableCheck{success: true}
+		mapper[fmt.Sprintf("rule-%d", i)] = checks[i]
+	}
+	e := &Engine{RuleCheckMapper: mapper}
+
+	runs := make([]*Run, runCount)
+	var wg sync.WaitGroup
+	for i := range runs {
+		runs[i] = e.NewRun()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results, err := runs[i].ExecuteRules([]Rule{fakeRule{name: fmt.Sprintf("rule-%d", i)}}, []string{})
+			if err != nil || len(results) != 1 || !results[0].Success {
+				t.Errorf("run %d: unexpected results %v, err %v", i, results, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// closing one run leaves the checks of the other runs open
+	if err := runs[0].Close(); err != nil {
+		t.Errorf("unexpected error when closing run: %v", err)
+	}
+	if !checks[0].closeCalled {
+		t.Errorf("the check of the closed run was not closed")
+	}
+	for i := 1; i < runCount; i++ {
+		if checks[i].closeCalled {
+			t.Errorf("the check of run %d was closed by another run", i)
+		}
+	}
+	// closing the engine's checks leaves the checks of the runs open
+	if err := e.CloseChecks(); err != nil {
+		t.Errorf("unexpected error when closing checks: %v", err)
+	}
+	for i := 1; i < runCount; i++ {
+		if checks[i].closeCalled {
+			t.Errorf("the check of run %d was closed by the engine", i)
+		}
+	}
+
+	for i := 1; i < runCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			runs[i].Close()
+		}(i)
+	}
+	wg.Wait()
+	for i := range checks {
+		if !checks[i].closeCalled {
+			t.Errorf("the check of run %d was not closed", i)
+		}
+	}
+}
+
+func TestEnginePlanRules(t *testing.T) {
+	rules := []Rule{
+		fakeRule{name: "always"},
+		fakeRule{name: "master on ubuntu", Meta: Meta{When: [][]string{{"master"}, {"ubuntu"}}}},
+		fakeRule{name: "worker", Meta: Meta{When: [][]string{{"worker", "ingress"}}}},
+		fakeRule{name: "master on centos", Meta: Meta{When: [][]string{{"master"}, {"centos", "rhel"}}}},
+		fakeRule{name: "empty condition", Meta: Meta{When: [][]string{{"master"}, {}}}},
+	}
+	// the mapper fails for every rule, so planning must not look up any checks
+	e := Engine{RuleCheckMapper: fakeRuleCheckMapper{err: errors.New("check should not be mapped")}}
+	plans := e.PlanRules(rules, []string{"master", "ubuntu"})
+	expected := []RulePlan{
+		{Rule: rules[0], Execute: true},
+		{Rule: rules[1], Execute: true},
+		{Rule: rules[2], UnmetCondition: []string{"worker", "ingress"}},
+		{Rule: rules[3], UnmetCondition: []string{"centos", "rhel"}},
+		{Rule: rules[4], UnmetCondition: []string{}},
+	}
+	if !reflect.DeepEqual(plans, expected) {
+		t.Errorf("expected %+v, got %+v", expected, plans)
+	}
+}
+
+type fakeDetailedCheck struct {
+	fakeCheck
+	details string
+}
+
+func (c fakeDetailedCheck) Details() string { return c.details }
+
+func TestEngineDetailedCheck(t *testing.T) {
+	e := Engine{
+		RuleCheckMapper: fakeRuleCheckMapper{check: fakeDetailedCheck{fakeCheck: fakeCheck{ok: true}, details: "version 3.6.8 was detected"}},
+	}
+	results, err := e.ExecuteRules([]Rule{fakeRule{name: "PassRule"}}, []string{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || !results[0].Success || results[0].Details != "version 3.6.8 was detected" {
+		t.Errorf("expected a passing result with the details of the check, got %+v", results)
+	}
+}
@@ -0,0 +1,48 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// The HTTPReachable rule declares that the given HTTP endpoint must be
+// reachable from the node
+type HTTPReachable struct {
+	Meta
+	URL     string
+	Timeout string
+	// CertsDir is a directory with the CA certificates of the endpoint,
+	// using the layout of /etc/docker/certs.d
+	CertsDir string
+}
+
+// Name is the name of the rule
+func (h HTTPReachable) Name() string {
+	return fmt.Sprintf("%s is reachable", h.URL)
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (h HTTPReachable) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (h HTTPReachable) Validate() []error {
+	errs := []error{}
+	if h.URL == "" {
+		errs = append(errs, errors.New("URL cannot be empty"))
+	} else if u, err := url.Parse(h.URL); err != nil {
+		errs = append(errs, fmt.Errorf("URL is invalid: %v", err))
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		errs = append(errs, errors.New("URL scheme must be http or https"))
+	}
+	if h.Timeout != "" {
+		if _, err := time.ParseDuration(h.Timeout); err != nil {
+			errs = append(errs, fmt.Errorf("Timeout is invalid: %v", err))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package rule
+
+import "testing"
+
+func TestHTTPReachableRuleValidation(t *testing.T) {
+	tests := []struct {
+		rule HTTPReachable
+		errs int
+	}{
+		{rule: HTTPReachable{}, errs: 1},
+		{rule: HTTPReachable{URL: "registry.local:5000"}, errs: 1},
+		{rule: HTTPReachable{URL: "ftp://registry.local"}, errs: 1},
+		{rule: HTTPReachable{URL: "https://registry.local", Timeout: "soon"}, errs: 1},
+		{rule: HTTPReachable{URL: "https://registry.local/v2/"}, errs: 0},
+		{rule: HTTPReachable{URL: "http://10.0.0.1:5000/v2/", Timeout: "5s"}, errs: 0},
+	}
+	for i, test := range tests {
+		if errs := test.rule.Validate(); len(errs) != test.errs {
+			t.Errorf("test %d: expected %d errors, but got %d: %v", i, test.errs, len(errs), errs)
+		}
+	}
+}
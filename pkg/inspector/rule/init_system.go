@@ -0,0 +1,43 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apprenda/kismatic/pkg/inspector/check"
+)
+
+// The InitSystemSupported rule declares that the node must be running one
+// of the supported init systems
+type InitSystemSupported struct {
+	Meta
+	SupportedInitSystems []string
+}
+
+// Name is the name of the rule
+func (i InitSystemSupported) Name() string {
+	return fmt.Sprintf("Init system is one of [%s]", strings.Join(i.SupportedInitSystems, ", "))
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (i InitSystemSupported) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (i InitSystemSupported) Validate() []error {
+	if len(i.SupportedInitSystems) == 0 {
+		return []error{errors.New("SupportedInitSystems cannot be empty")}
+	}
+	errs := []error{}
+	for _, s := range i.SupportedInitSystems {
+		switch check.InitSystem(s) {
+		case check.Systemd, check.SysVinit, check.OpenRC:
+		default:
+			errs = append(errs, fmt.Errorf("%q is not a known init system. Options are: %s, %s, %s", s, check.Systemd, check.SysVinit, check.OpenRC))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
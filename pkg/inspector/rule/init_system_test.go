@@ -0,0 +1,22 @@
+package rule
+
+import "testing"
+
+func TestInitSystemSupportedRuleValidation(t *testing.T) {
+	tests := []struct {
+		supported []string
+		errs      int
+	}{
+		{supported: nil, errs: 1},
+		{supported: []string{"upstart"}, errs: 1},
+		{supported: []string{"systemd", "runit", "s6"}, errs: 2},
+		{supported: []string{"systemd"}, errs: 0},
+		{supported: []string{"systemd", "sysvinit", "openrc"}, errs: 0},
+	}
+	for _, test := range tests {
+		r := InitSystemSupported{SupportedInitSystems: test.supported}
+		if errs := r.Validate(); len(errs) != test.errs {
+			t.Errorf("supported %v: expected %d errors, but got %d: %v", test.supported, test.errs, len(errs), errs)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// The InotifyWatchLimit rule declares that the kernel must allow each user
+// to hold at least the given number of inotify watches.
+type InotifyWatchLimit struct {
+	Meta
+	MinimumWatches string
+}
+
+// Name is the name of the rule
+func (i InotifyWatchLimit) Name() string {
+	return fmt.Sprintf("fs.inotify.max_user_watches is at least %s", i.MinimumWatches)
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (i InotifyWatchLimit) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (i InotifyWatchLimit) Validate() []error {
+	if i.MinimumWatches == "" {
+		return []error{errors.New("MinimumWatches cannot be empty")}
+	}
+	if _, err := i.minimumWatchesAsUint64(); err != nil {
+		return []error{fmt.Errorf("MinimumWatches contains an invalid unsigned integer: %v", err)}
+	}
+	return nil
+}
+
+func (i InotifyWatchLimit) minimumWatchesAsUint64() (uint64, error) {
+	return strconv.ParseUint(i.MinimumWatches, 10, 0)
+}
@@ -0,0 +1,21 @@
+package rule
+
+import "testing"
+
+func TestInotifyWatchLimitRuleValidation(t *testing.T) {
+	tests := []struct {
+		minimum string
+		errs    int
+	}{
+		{minimum: "", errs: 1},
+		{minimum: "lots", errs: 1},
+		{minimum: "-1", errs: 1},
+		{minimum: "524288", errs: 0},
+	}
+	for _, test := range tests {
+		r := InotifyWatchLimit{MinimumWatches: test.minimum}
+		if errs := r.Validate(); len(errs) != test.errs {
+			t.Errorf("minimum %q: expected %d errors, but got %d", test.minimum, test.errs, len(errs))
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/apprenda/kismatic/pkg/inspector/check"
+)
+
+// The InterpreterPresent rule declares that the given interpreter must be
+// installed on the node, with at least the given version. This is used to
+// verify the runtime that remote execution depends on, such as python.
+type InterpreterPresent struct {
+	Meta
+	Interpreter    string
+	MinimumVersion string
+}
+
+// Name is the name of the rule
+func (i InterpreterPresent) Name() string {
+	return fmt.Sprintf("%s version %s or later is installed", i.Interpreter, i.MinimumVersion)
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (i InterpreterPresent) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (i InterpreterPresent) Validate() []error {
+	errs := []error{}
+	if i.Interpreter == "" {
+		errs = append(errs, errors.New("Interpreter cannot be empty"))
+	}
+	if i.MinimumVersion == "" {
+		errs = append(errs, errors.New("MinimumVersion cannot be empty"))
+	} else if _, err := check.ParseVersion(i.MinimumVersion); err != nil {
+		errs = append(errs, fmt.Errorf("MinimumVersion is invalid: %v", err))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package rule
+
+import "testing"
+
+func TestInterpreterPresentRuleValidation(t *testing.T) {
+	tests := []struct {
+		rule InterpreterPresent
+		errs int
+	}{
+		{rule: InterpreterPresent{}, errs: 2},
+		{rule: InterpreterPresent{Interpreter: "python3"}, errs: 1},
+		{rule: InterpreterPresent{Interpreter: "python3", MinimumVersion: "three"}, errs: 1},
+		{rule: InterpreterPresent{Interpreter: "python3", MinimumVersion: "3.5"}, errs: 0},
+		{rule: InterpreterPresent{Interpreter: "python3", MinimumVersion: "3"}, errs: 0},
+	}
+	for i, test := range tests {
+		if errs := test.rule.Validate(); len(errs) != test.errs {
+			t.Errorf("test %d: expected %d errors, but got %d: %v", i, test.errs, len(errs), errs)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The KernelModuleLoaded rule declares that the given kernel module
+// must be loaded on the node
+type KernelModuleLoaded struct {
+	Meta
+	Module string
+}
+
+// Name is the name of the rule
+func (k KernelModuleLoaded) Name() string {
+	return fmt.Sprintf("Kernel module %s is loaded", k.Module)
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (k KernelModuleLoaded) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (k KernelModuleLoaded) Validate() []error {
+	if k.Module == "" {
+		return []error{errors.New("Module cannot be empty")}
+	}
+	return nil
+}
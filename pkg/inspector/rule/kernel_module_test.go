@@ -0,0 +1,14 @@
+package rule
+
+import "testing"
+
+func TestKernelModuleLoadedRuleValidation(t *testing.T) {
+	r := KernelModuleLoaded{}
+	if errs := r.Validate(); len(errs) != 1 {
+		t.Errorf("expected 1 error, but got %d", len(errs))
+	}
+	r.Module = "ip_vs"
+	if errs := r.Validate(); len(errs) != 0 {
+		t.Errorf("expected 0 errors, but got %d", len(errs))
+	}
+}
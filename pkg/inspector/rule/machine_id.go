@@ -0,0 +1,20 @@
+package rule
+
+// The UniqueMachineID rule declares that the node's product UUID and the MAC
+// addresses of its network interfaces must be usable as unique identifiers.
+// Nodes cloned from a VM template often share them, which confuses the
+// kubelet and the pod network.
+type UniqueMachineID struct {
+	Meta
+}
+
+// Name is the name of the rule
+func (u UniqueMachineID) Name() string {
+	return "Product UUID and MAC addresses are unique"
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (u UniqueMachineID) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (u UniqueMachineID) Validate() []error { return nil }
@@ -0,0 +1,28 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/apprenda/kismatic/pkg/inspector/check"
+)
+
+func TestUniqueMachineIDRule(t *testing.T) {
+	rules, err := UnmarshalRulesYAML([]byte("- kind: UniqueMachineID\n  when: [[\"worker\"]]\n"))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling rule: %v", err)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rule, but got %d", len(rules))
+	}
+	r, ok := rules[0].(UniqueMachineID)
+	if !ok {
+		t.Fatalf("expected a UniqueMachineID rule, but got %T", rules[0])
+	}
+	c, err := DefaultCheckMapper{}.GetCheckForRule(r)
+	if err != nil {
+		t.Fatalf("unexpected error getting check: %v", err)
+	}
+	if _, ok := c.(*check.MachineIDCheck); !ok {
+		t.Errorf("expected a MachineIDCheck, but got %T", c)
+	}
+}
@@ -0,0 +1,47 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The PathOnMount rule declares that the given path must reside on the
+// given mount point. Optionally, the device that backs the mount point
+// can also be asserted.
+type PathOnMount struct {
+	Meta
+	Path       string
+	MountPoint string
+	Device     string
+}
+
+// Name is the name of the rule
+func (p PathOnMount) Name() string {
+	if p.Device != "" {
+		return fmt.Sprintf("Path %s is on mount %s (device %s)", p.Path, p.MountPoint, p.Device)
+	}
+	return fmt.Sprintf("Path %s is on mount %s", p.Path, p.MountPoint)
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (p PathOnMount) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (p PathOnMount) Validate() []error {
+	errs := []error{}
+	if p.Path == "" {
+		errs = append(errs, errors.New("Path cannot be empty"))
+	} else if !strings.HasPrefix(p.Path, "/") {
+		errs = append(errs, errors.New("Path must start with /"))
+	}
+	if p.MountPoint == "" {
+		errs = append(errs, errors.New("MountPoint cannot be empty"))
+	} else if !strings.HasPrefix(p.MountPoint, "/") {
+		errs = append(errs, errors.New("MountPoint must start with /"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
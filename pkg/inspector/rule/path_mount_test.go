@@ -0,0 +1,26 @@
+package rule
+
+import "testing"
+
+func TestPathOnMountRuleValidation(t *testing.T) {
+	p := PathOnMount{}
+	if errs := p.Validate(); len(errs) != 2 {
+		t.Errorf("expected 2 errors, but got %d", len(errs))
+	}
+
+	p.Path = "var/lib/etcd"
+	p.MountPoint = "var/lib/etcd"
+	if errs := p.Validate(); len(errs) != 2 {
+		t.Errorf("expected 2 errors, but got %d", len(errs))
+	}
+
+	p.Path = "/var/lib/etcd"
+	if errs := p.Validate(); len(errs) != 1 {
+		t.Errorf("expected 1 error, but got %d", len(errs))
+	}
+
+	p.MountPoint = "/var/lib/etcd"
+	if errs := p.Validate(); len(errs) != 0 {
+		t.Errorf("expected 0 errors, but got %d", len(errs))
+	}
+}
@@ -0,0 +1,113 @@
+package rule
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/apprenda/kismatic/pkg/inspector/check"
+)
+
+const (
+	defaultReportTimeout = 10 * time.Second
+	kernelReleaseFile    = "/proc/sys/kernel/osrelease"
+)
+
+// A ResultReporter sends the results of an inspection to an external system
+type ResultReporter interface {
+	Report(results []Result, facts []string) error
+}
+
+// Report is the payload sent to a remote collector
+type Report struct {
+	// Node is the identity of the node that was inspected
+	Node string
+	// Facts are the facts that were used when deciding which rules to run
+	Facts []string
+	// Metadata about the node, such as its distribution and kernel release
+	Metadata map[string]string `json:",omitempty"`
+	// Timestamp is the time at which the report was generated
+	Timestamp time.Time
+	// Results of the inspection
+	Results []Result
+}
+
+// The HTTPResultReporter POSTs the results of an inspection to a collector
+// as a JSON-encoded Report.
+type HTTPResultReporter struct {
+	// URL of the collector
+	URL string
+	// Node is the identity of the inspected node. Defaults to the hostname.
+	Node string
+	// Metadata about the inspected node that is sent with every report,
+	// such as the metadata returned by NodeMetadata
+	Metadata map[string]string
+	// Timeout for the request to the collector. Defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+// Report the results to the collector
+func (r HTTPResultReporter) Report(results []Result, facts []string) error {
+	node := r.Node
+	if node == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("error getting hostname: %v", err)
+		}
+		node = hostname
+	}
+	report := Report{
+		Node:      node,
+		Facts:     facts,
+		Metadata:  r.Metadata,
+		Timestamp: time.Now().UTC(),
+		Results:   results,
+	}
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("error marshaling report: %v", err)
+	}
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = defaultReportTimeout
+	}
+	client := http.Client{Timeout: timeout}
+	resp, err := client.Post(r.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error sending report to %q: %v", r.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("collector at %q responded with status %q", r.URL, resp.Status)
+	}
+	return nil
+}
+
+// NodeMetadata returns metadata about the local node: its operating system,
+// architecture, distribution, init system, kernel release and product UUID.
+// Metadata that cannot be detected is left out.
+func NodeMetadata() map[string]string {
+	md := map[string]string{
+		"os":   runtime.GOOS,
+		"arch": runtime.GOARCH,
+	}
+	if distro, err := check.DetectDistro(); err == nil {
+		md["distro"] = string(distro)
+	}
+	if initSystem, err := check.DetectInitSystem(); err == nil {
+		md["initSystem"] = string(initSystem)
+	}
+	if b, err := ioutil.ReadFile(kernelReleaseFile); err == nil {
+		md["kernelRelease"] = strings.TrimSpace(string(b))
+	}
+	if id, err := (check.MachineIDCheck{}).MachineID(); err == nil && id.ProductUUID != "" {
+		md["productUUID"] = id.ProductUUID
+	}
+	return md
+}
@@ -0,0 +1,94 @@
+package rule
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHTTPResultReporter(t *testing.T) {
+	received := make(chan Report, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var r Report
+		if err := json.NewDecoder(req.Body).Decode(&r); err != nil {
+			t.Errorf("error decoding report: %v", err)
+		}
+		received <- r
+	}))
+	defer srv.Close()
+
+	results := []Result{{Name: "SuccessRule", Success: true}}
+	facts := []string{"master", "ubuntu"}
+	metadata := map[string]string{"distro": "ubuntu", "kernelRelease": "4.4.0-131-generic"}
+	r := HTTPResultReporter{URL: srv.URL, Node: "node01", Metadata: metadata}
+	if err := r.Report(results, facts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	report := <-received
+	if report.Node != "node01" {
+		t.Errorf("expected node %q, but got %q", "node01", report.Node)
+	}
+	if !reflect.DeepEqual(report.Facts, facts) {
+		t.Errorf("expected facts %v, but got %v", facts, report.Facts)
+	}
+	if !reflect.DeepEqual(report.Metadata, metadata) {
+		t.Errorf("expected metadata %v, but got %v", metadata, report.Metadata)
+	}
+	if !reflect.DeepEqual(report.Results, results) {
+		t.Errorf("expected results %v, but got %v", results, report.Results)
+	}
+	if report.Timestamp.IsZero() {
+		t.Errorf("expected a timestamp, but got none")
+	}
+}
+
+func TestHTTPResultReporterErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	r := HTTPResultReporter{URL: srv.URL, Node: "node01"}
+	if err := r.Report(nil, nil); err == nil {
+		t.Errorf("expected an error, but didn't get one")
+	}
+}
+
+type fakeReporter struct {
+	reported chan []Result
+}
+
+func (r fakeReporter) Report(results []Result, facts []string) error {
+	r.reported <- results
+	return nil
+}
+
+func TestEngineReportsResults(t *testing.T) {
+	reporter := fakeReporter{reported: make(chan []Result, 1)}
+	e := Engine{
+		RuleCheckMapper: fakeRuleCheckMapper{check: fakeCheck{ok: true}},
+		Reporter:        reporter,
+	}
+	results, err := e.ExecuteRules([]Rule{fakeRule{name: "SuccessRule"}}, []string{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e.WaitForReports()
+	select {
+	case reported := <-reporter.reported:
+		if !reflect.DeepEqual(reported, results) {
+			t.Errorf("expected reported results %v, but got %v", results, reported)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("results were not reported")
+	}
+}
+
+func TestNodeMetadata(t *testing.T) {
+	md := NodeMetadata()
+	if md["os"] == "" || md["arch"] == "" {
+		t.Errorf("expected the operating system and architecture, but got %v", md)
+	}
+}
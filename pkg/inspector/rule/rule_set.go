@@ -21,6 +21,12 @@ This rule will be executed when the node has these facts:
 
 // DefaultRuleSet is the list of rules that are built into the inspector
 const defaultRuleSet = `---
+# Services are managed with systemd
+- kind: InitSystemSupported
+  when: []
+  supportedInitSystems:
+  - systemd
+
 - kind: FreeSpace
   path: /
   minimumBytes: 1000000000
@@ -52,6 +58,11 @@ const defaultRuleSet = `---
 - kind: DockerInPath
   when:
   - ["etcd", "master", "worker", "ingress", "storage"]
+
+# Nodes cloned from a VM template must not share identifiers
+- kind: UniqueMachineID
+  when:
+  - ["master", "worker", "ingress", "storage"]
   
 # Ports used by etcd are available
 - kind: TCPPortAvailable
@@ -459,7 +470,46 @@ const defaultRuleSet = `---
   - ["ubuntu"]
   packageName: glusterfs-server
   packageVersion: 3.8.15-ubuntu1~xenial1
-`
+{{if eq .kube_proxy_mode "ipvs"}}
+# Kernel modules required by kube-proxy in IPVS mode
+- kind: KernelModuleLoaded
+  when:
+  - ["master", "worker", "ingress", "storage"]
+  module: ip_vs
+- kind: KernelModuleLoaded
+  when:
+  - ["master", "worker", "ingress", "storage"]
+  module: ip_vs_rr
+- kind: KernelModuleLoaded
+  when:
+  - ["master", "worker", "ingress", "storage"]
+  module: ip_vs_wrr
+- kind: KernelModuleLoaded
+  when:
+  - ["master", "worker", "ingress", "storage"]
+  module: ip_vs_sh
+- kind: KernelModuleLoaded
+  when:
+  - ["master", "worker", "ingress", "storage"]
+  module: nf_conntrack_ipv4
+{{end}}
+{{- if .docker_registry_mirrors}}
+# Docker registry mirrors are reachable
+{{- range split .docker_registry_mirrors ";"}}
+- kind: HTTPReachable
+  when:
+  - ["etcd", "master", "worker", "ingress", "storage"]
+  url: "{{trimSuffix . "/"}}/v2/"
+  certsDir: /etc/docker/certs.d
+{{- end}}
+{{end}}
+{{- if .oidc_issuer_url}}
+# The OIDC provider is reachable from the API servers
+- kind: HTTPReachable
+  when:
+  - ["master"]
+  url: "{{trimSuffix .oidc_issuer_url "/"}}/.well-known/openid-configuration"
+{{end}}`
 
 const upgradeRuleSet = `---
 - kind: FreeSpace
@@ -557,11 +607,33 @@ const upgradeRuleSet = `---
   - ["ubuntu"]
   packageName: glusterfs-server
   packageVersion: 3.8.15-ubuntu1~xenial1
-`
+{{if eq .kube_proxy_mode "ipvs"}}
+# Kernel modules required by kube-proxy in IPVS mode
+- kind: KernelModuleLoaded
+  when:
+  - ["master", "worker", "ingress", "storage"]
+  module: ip_vs
+- kind: KernelModuleLoaded
+  when:
+  - ["master", "worker", "ingress", "storage"]
+  module: ip_vs_rr
+- kind: KernelModuleLoaded
+  when:
+  - ["master", "worker", "ingress", "storage"]
+  module: ip_vs_wrr
+- kind: KernelModuleLoaded
+  when:
+  - ["master", "worker", "ingress", "storage"]
+  module: ip_vs_sh
+- kind: KernelModuleLoaded
+  when:
+  - ["master", "worker", "ingress", "storage"]
+  module: nf_conntrack_ipv4
+{{end}}`
 
 // DefaultRules returns the list of rules that are built into the inspector
 func DefaultRules(vars map[string]string) []Rule {
-	tmpl, err := template.New("").Parse(defaultRuleSet)
+	tmpl, err := template.New("").Funcs(template.FuncMap{"split": strings.Split, "trimSuffix": strings.TrimSuffix}).Parse(defaultRuleSet)
 	if err != nil {
 		panic(fmt.Errorf("error parsing rules: %v", err))
 	}
@@ -1,12 +1,15 @@
 package rule
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestDefaultRules(t *testing.T) {
 	// This will panic if there are errors in the default rule
 	rules := DefaultRules(map[string]string{"kubernetes_yum_version": "1.10.5-0", "kubernetes_deb_version": "1.10.5-00"})
-	if len(rules) != 75 {
-		t.Errorf("expected to have %d rules, instead got %d", 75, len(rules))
+	if len(rules) != 77 {
+		t.Errorf("expected to have %d rules, instead got %d", 77, len(rules))
 	}
 	for _, r := range rules {
 		if errs := r.Validate(); len(errs) != 0 {
@@ -15,6 +18,59 @@ func TestDefaultRules(t *testing.T) {
 	}
 }
 
+func TestDefaultRulesIPVS(t *testing.T) {
+	rules := DefaultRules(map[string]string{"kubernetes_yum_version": "1.10.5-0", "kubernetes_deb_version": "1.10.5-00", "kube_proxy_mode": "ipvs"})
+	if len(rules) != 82 {
+		t.Errorf("expected to have %d rules, instead got %d", 82, len(rules))
+	}
+	var modules int
+	for _, r := range rules {
+		if _, ok := r.(KernelModuleLoaded); ok {
+			modules++
+		}
+	}
+	if modules != 5 {
+		t.Errorf("expected to have %d kernel module rules, instead got %d", 5, modules)
+	}
+}
+
+func TestDefaultRulesRegistryMirrors(t *testing.T) {
+	rules := DefaultRules(map[string]string{"kubernetes_yum_version": "1.10.5-0", "kubernetes_deb_version": "1.10.5-00", "docker_registry_mirrors": "https://mirror-a.local;http://10.0.0.1:5000/"})
+	if len(rules) != 79 {
+		t.Errorf("expected to have %d rules, instead got %d", 79, len(rules))
+	}
+	var urls []string
+	for _, r := range rules {
+		if h, ok := r.(HTTPReachable); ok {
+			urls = append(urls, h.URL)
+			if h.CertsDir != "/etc/docker/certs.d" {
+				t.Errorf("expected the mirror rule to use the docker certificates, instead got %q", h.CertsDir)
+			}
+		}
+	}
+	expected := []string{"https://mirror-a.local/v2/", "http://10.0.0.1:5000/v2/"}
+	if !reflect.DeepEqual(urls, expected) {
+		t.Errorf("expected HTTPReachable rules for %v, instead got %v", expected, urls)
+	}
+}
+
+func TestDefaultRulesOIDCIssuer(t *testing.T) {
+	rules := DefaultRules(map[string]string{"kubernetes_yum_version": "1.10.5-0", "kubernetes_deb_version": "1.10.5-00", "oidc_issuer_url": "https://accounts.example.com/"})
+	var urls []string
+	for _, r := range rules {
+		if h, ok := r.(HTTPReachable); ok {
+			urls = append(urls, h.URL)
+			if !reflect.DeepEqual(h.When, [][]string{{"master"}}) {
+				t.Errorf("expected the OIDC issuer rule to run on masters only, instead got %v", h.When)
+			}
+		}
+	}
+	expected := []string{"https://accounts.example.com/.well-known/openid-configuration"}
+	if !reflect.DeepEqual(urls, expected) {
+		t.Errorf("expected HTTPReachable rules for %v, instead got %v", expected, urls)
+	}
+}
+
 func TestUpgradeRules(t *testing.T) {
 	// This will panic if there are errors in the upgrade rule
 	rules := UpgradeRules(map[string]string{"kubernetes_yum_version": "1.10.5-0", "kubernetes_deb_version": "1.10.5-00"})
@@ -27,3 +83,10 @@ func TestUpgradeRules(t *testing.T) {
 		}
 	}
 }
+
+func TestUpgradeRulesIPVS(t *testing.T) {
+	rules := UpgradeRules(map[string]string{"kubernetes_yum_version": "1.10.5-0", "kubernetes_deb_version": "1.10.5-00", "kube_proxy_mode": "ipvs"})
+	if len(rules) != 21 {
+		t.Errorf("expected to have %d rules, instead got %d", 21, len(rules))
+	}
+}
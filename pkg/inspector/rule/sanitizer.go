@@ -0,0 +1,60 @@
+package rule
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const redacted = "[REDACTED]"
+
+// A Sanitizer redacts sensitive data, such as tokens or passwords, from the
+// messages of the results. A nil Sanitizer leaves the results unchanged.
+type Sanitizer struct {
+	patterns []*regexp.Regexp
+}
+
+// NewSanitizer returns a sanitizer that redacts the matches of the patterns,
+// which are regular expressions in the RE2 syntax of the Go regexp package
+func NewSanitizer(patterns []string) (*Sanitizer, error) {
+	s := &Sanitizer{}
+	for _, p := range patterns {
+		r, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %v", p, err)
+		}
+		s.patterns = append(s.patterns, r)
+	}
+	return s, nil
+}
+
+// Sanitize returns the result with the matches of the patterns redacted from
+// its error, remediation and details
+func (s *Sanitizer) Sanitize(r Result) Result {
+	if s == nil {
+		return r
+	}
+	r.Error = s.redact(r.Error)
+	r.Remediation = s.redact(r.Remediation)
+	r.Details = s.redact(r.Details)
+	return r
+}
+
+// SanitizeResults returns the results with the matches of the patterns
+// redacted from their errors, remediations and details
+func (s *Sanitizer) SanitizeResults(results []Result) []Result {
+	if s == nil {
+		return results
+	}
+	sanitized := make([]Result, 0, len(results))
+	for _, r := range results {
+		sanitized = append(sanitized, s.Sanitize(r))
+	}
+	return sanitized
+}
+
+func (s *Sanitizer) redact(msg string) string {
+	for _, r := range s.patterns {
+		msg = r.ReplaceAllLiteralString(msg, redacted)
+	}
+	return msg
+}
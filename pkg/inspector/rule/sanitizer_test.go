@@ -0,0 +1,59 @@
+package rule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSanitizer(t *testing.T) {
+	s, err := NewSanitizer([]string{`token=\S+`, `(?i)password:\s*\S+`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := s.Sanitize(Result{
+		Name:        "FileContent",
+		Error:       "found token=abc123 in /etc/config",
+		Remediation: "remove Password: hunter2 from the file",
+	})
+	expected := Result{
+		Name:        "FileContent",
+		Error:       "found [REDACTED] in /etc/config",
+		Remediation: "remove [REDACTED] from the file",
+	}
+	if !reflect.DeepEqual(r, expected) {
+		t.Errorf("expected %+v, got %+v", expected, r)
+	}
+}
+
+func TestSanitizerInvalidPattern(t *testing.T) {
+	if _, err := NewSanitizer([]string{"("}); err == nil {
+		t.Errorf("expected an error for an invalid pattern")
+	}
+}
+
+func TestNilSanitizer(t *testing.T) {
+	var s *Sanitizer
+	results := []Result{{Name: "a", Error: "token=abc123"}}
+	if got := s.SanitizeResults(results); !reflect.DeepEqual(got, results) {
+		t.Errorf("expected the results to be unchanged, got %+v", got)
+	}
+}
+
+func TestEngineSanitizesResults(t *testing.T) {
+	s, err := NewSanitizer([]string{`secret-\w+`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e := Engine{
+		RuleCheckMapper: fakeRuleCheckMapper{check: fakeCheck{err: errors.New("matched secret-value in file")}},
+		Sanitizer:       s,
+	}
+	results, err := e.ExecuteRules([]Rule{fakeRule{name: "FailRule"}}, []string{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Error != "matched [REDACTED] in file" {
+		t.Errorf("expected the error to be redacted, got %+v", results)
+	}
+}
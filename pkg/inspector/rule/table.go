@@ -0,0 +1,77 @@
+package rule
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+)
+
+// TableOptions control how WriteTable renders the results
+type TableOptions struct {
+	// Color enables ANSI colors for the status of each result
+	Color bool
+	// Categories that were selected for the inspection, if any.
+	// They are listed in the summary.
+	Categories []string
+}
+
+// WriteTable writes the results as a table that is meant to be read by an
+// operator. Failed rules are listed before the rules that passed, and the
+// table is followed by a summary of the counts.
+func WriteTable(w io.Writer, results []Result, opts TableOptions) error {
+	var failed, passed []Result
+	for _, r := range results {
+		if r.Success {
+			passed = append(passed, r)
+		} else {
+			failed = append(failed, r)
+		}
+	}
+	tw := tabwriter.NewWriter(w, 1, 8, 4, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tSTATUS\tMESSAGE\tREMEDIATION")
+	for _, r := range failed {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Name, status("FAIL", color.FgRed, opts), orDash(message(r)), orDash(r.Remediation))
+	}
+	for _, r := range passed {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Name, status("PASS", color.FgGreen, opts), orDash(message(r)), orDash(r.Remediation))
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("error writing results table: %v", err)
+	}
+	summary := fmt.Sprintf("%d checks: %d passed, %d failed", len(results), len(passed), len(failed))
+	if len(opts.Categories) > 0 {
+		summary = fmt.Sprintf("%s (categories: %s)", summary, strings.Join(opts.Categories, ", "))
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", summary)
+	return err
+}
+
+// the color codes are the same length for every status, so the columns
+// remain aligned when colors are enabled
+func status(s string, attr color.Attribute, opts TableOptions) string {
+	if !opts.Color {
+		return s
+	}
+	c := color.New(attr)
+	// the caller decides whether to color the output, regardless of stdout
+	c.EnableColor()
+	return c.SprintFunc()(s)
+}
+
+// the error of the result, or its details when there is no error
+func message(r Result) string {
+	if r.Error != "" {
+		return r.Error
+	}
+	return r.Details
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
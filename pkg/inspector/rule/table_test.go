@@ -0,0 +1,59 @@
+package rule
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteTable(t *testing.T) {
+	results := []Result{
+		{Name: "Docker is installed", Success: true, Details: "docker version 17.03.2 was detected"},
+		{Name: "Port 6443 is available", Success: false, Error: "port is in use", Remediation: "stop the process listening on port 6443"},
+		{Name: "/ has 1GB free", Success: false},
+	}
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, results, TableOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 6 lines, got %d:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[1], "Port 6443 is available") || !strings.Contains(lines[1], "stop the process") {
+		t.Errorf("expected the first failure to be listed first, got %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "/ has 1GB free") || !strings.HasSuffix(strings.TrimSpace(lines[2]), "-") {
+		t.Errorf("expected an empty message and remediation to be shown as '-', got %q", lines[2])
+	}
+	if !strings.HasPrefix(lines[3], "Docker is installed") || !strings.Contains(lines[3], "PASS") || !strings.Contains(lines[3], "17.03.2") {
+		t.Errorf("expected the passing rule to be listed last, got %q", lines[3])
+	}
+	if lines[5] != "3 checks: 1 passed, 2 failed" {
+		t.Errorf("unexpected summary %q", lines[5])
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("expected no color codes when color is disabled")
+	}
+}
+
+func TestWriteTableColor(t *testing.T) {
+	results := []Result{{Name: "a", Success: true}, {Name: "b", Success: false}}
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, results, TableOptions{Color: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\x1b[31mFAIL\x1b[0m") || !strings.Contains(buf.String(), "\x1b[32mPASS\x1b[0m") {
+		t.Errorf("expected colored statuses, got %q", buf.String())
+	}
+}
+
+func TestWriteTableNoResults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, nil, TableOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "0 checks: 0 passed, 0 failed\n") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
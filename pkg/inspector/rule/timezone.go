@@ -0,0 +1,29 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The TimeZoneMatches rule declares that the time zone configured on the
+// node must be the given time zone
+type TimeZoneMatches struct {
+	Meta
+	TimeZone string
+}
+
+// Name is the name of the rule
+func (t TimeZoneMatches) Name() string {
+	return fmt.Sprintf("Time zone is %s", t.TimeZone)
+}
+
+// IsRemoteRule returns true if the rule is to be run from outside of the node
+func (t TimeZoneMatches) IsRemoteRule() bool { return false }
+
+// Validate the rule
+func (t TimeZoneMatches) Validate() []error {
+	if t.TimeZone == "" {
+		return []error{errors.New("TimeZone cannot be empty")}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package rule
+
+import "testing"
+
+func TestTimeZoneMatchesRuleValidation(t *testing.T) {
+	r := TimeZoneMatches{}
+	if errs := r.Validate(); len(errs) != 1 {
+		t.Errorf("expected 1 error, but got %d", len(errs))
+	}
+	r.TimeZone = "UTC"
+	if errs := r.Validate(); len(errs) != 0 {
+		t.Errorf("expected no errors, but got %v", errs)
+	}
+}
@@ -4,6 +4,9 @@ package rule
 type Meta struct {
 	Kind string
 	When [][]string
+	// Categories of the rule, such as "networking". When empty, the rule
+	// belongs to the default category of its kind.
+	Categories []string `yaml:",omitempty" json:",omitempty"`
 }
 
 // GetRuleMeta returns the rule's metadata
@@ -29,4 +32,6 @@ type Result struct {
 	Error string
 	// Remediation contains potential remediation steps for the rule
 	Remediation string
+	// Details about what the check found, such as a detected version
+	Details string `json:",omitempty"`
 }
@@ -17,6 +17,9 @@ type Server struct {
 	Port int
 	// NodeFacts are the facts that apply to the node where the server is running
 	NodeFacts []string
+	// Sanitizer is optional. When set, it redacts sensitive data from the
+	// results before they are sent to the client.
+	Sanitizer *rule.Sanitizer
 	// RulesEngine for running inspector rules
 	rulesEngine *rule.Engine
 }
@@ -39,6 +42,10 @@ func NewServer(nodeFacts []string, port int, packageInstallationDisabled bool, d
 		return nil, fmt.Errorf("error building server: %v", err)
 	}
 	s.NodeFacts = append(nodeFacts, string(distro))
+	// rules that depend on the init system can be gated on it
+	if initSystem, err := check.DetectInitSystem(); err == nil {
+		s.NodeFacts = append(s.NodeFacts, string(initSystem))
+	}
 	pkgMgr, err := check.NewPackageManager(distro)
 	if err != nil {
 		return nil, fmt.Errorf("error building server: %v", err)
@@ -57,6 +64,7 @@ func NewServer(nodeFacts []string, port int, packageInstallationDisabled bool, d
 
 // Start the server
 func (s *Server) Start() error {
+	s.rulesEngine.Sanitizer = s.Sanitizer
 	mux := http.NewServeMux()
 	// Execute endpoint
 	mux.HandleFunc(executeEndpoint, func(w http.ResponseWriter, req *http.Request) {
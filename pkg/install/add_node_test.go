@@ -526,7 +526,7 @@ func (f *fakePKI) GenerateProxyClientCA(p *Plan) (*tls.CA, error) {
 func (f *fakePKI) GenerateClusterCertificates(p *Plan, clusterCA *tls.CA, proxyClientCA *tls.CA) error {
 	return f.err
 }
-func (f *fakePKI) GenerateCertificate(name string, validityPeriod string, commonName string, subjectAlternateNames []string, organizations []string, ca *tls.CA, overwrite bool) (bool, error) {
+func (f *fakePKI) GenerateCertificate(name string, validityPeriod string, commonName string, subjectAlternateNames []string, organizations []string, ca *tls.CA, certs CertsConfig, overwrite bool) (bool, error) {
 	return false, f.err
 }
 
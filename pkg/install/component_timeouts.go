@@ -0,0 +1,52 @@
+package install
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultEtcdTimeout         = 15 * time.Second
+	defaultControlPlaneTimeout = 5 * time.Minute
+	defaultCNITimeout          = 2 * time.Minute
+	defaultSystemPodsTimeout   = 5 * time.Minute
+	// the readiness checks poll the components every few seconds
+	minimumComponentTimeout = 10 * time.Second
+)
+
+func (t *ComponentTimeouts) validate() (bool, []error) {
+	v := newValidator()
+	timeouts := []struct {
+		component string
+		timeout   string
+	}{
+		{component: "etcd", timeout: t.Etcd},
+		{component: "control plane", timeout: t.ControlPlane},
+		{component: "CNI", timeout: t.CNI},
+		{component: "system pods", timeout: t.SystemPods},
+	}
+	for _, ct := range timeouts {
+		if ct.timeout == "" {
+			continue
+		}
+		d, err := time.ParseDuration(ct.timeout)
+		if err != nil {
+			v.addError(fmt.Errorf("Invalid %s timeout %q provided: %v", ct.component, ct.timeout, err))
+			continue
+		}
+		if d < minimumComponentTimeout {
+			v.addError(fmt.Errorf("The %s timeout %q is not valid, must be at least %v", ct.component, ct.timeout, minimumComponentTimeout))
+		}
+	}
+	return v.valid()
+}
+
+// timeoutSeconds returns the timeout in seconds, or the default when the
+// timeout is not set
+func timeoutSeconds(timeout string, def time.Duration) int {
+	d, err := time.ParseDuration(timeout)
+	if timeout == "" || err != nil {
+		d = def
+	}
+	return int(d.Seconds())
+}
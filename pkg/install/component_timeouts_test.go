@@ -0,0 +1,58 @@
+package install
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComponentTimeoutsValidation(t *testing.T) {
+	tests := []struct {
+		timeouts ComponentTimeouts
+		valid    bool
+	}{
+		{timeouts: ComponentTimeouts{}, valid: true},
+		{timeouts: ComponentTimeouts{Etcd: "2m", ControlPlane: "15m", CNI: "10m"}, valid: true},
+		{timeouts: ComponentTimeouts{Etcd: "2"}, valid: false},
+		{timeouts: ComponentTimeouts{ControlPlane: "5s"}, valid: false},
+		{timeouts: ComponentTimeouts{CNI: "-1m"}, valid: false},
+		{timeouts: ComponentTimeouts{SystemPods: "10m"}, valid: true},
+		{timeouts: ComponentTimeouts{SystemPods: "1s"}, valid: false},
+	}
+	for i, test := range tests {
+		ok, errs := test.timeouts.validate()
+		if ok != test.valid {
+			t.Errorf("test %d: expect %t, but got %t: %v", i, test.valid, ok, errs)
+		}
+	}
+}
+
+func TestTimeoutSeconds(t *testing.T) {
+	if s := timeoutSeconds("", 5*time.Minute); s != 300 {
+		t.Errorf("expected the default of 300 seconds, but got %d", s)
+	}
+	if s := timeoutSeconds("90s", 5*time.Minute); s != 90 {
+		t.Errorf("expected 90 seconds, but got %d", s)
+	}
+}
+
+func TestClusterCatalogTimeouts(t *testing.T) {
+	p := validPlan()
+	p.Cluster.Timeouts = ComponentTimeouts{Etcd: "30s", ControlPlane: "10m", CNI: "3m", SystemPods: "15m"}
+	e := ansibleExecutor{certsDir: "/tmp"}
+	cc, err := e.buildClusterCatalog(&p)
+	if err != nil {
+		t.Fatalf("unexpected error building the cluster catalog: %v", err)
+	}
+	if cc.EtcdTimeoutSeconds != 30 {
+		t.Errorf("expected an etcd timeout of 30 seconds, but got %d", cc.EtcdTimeoutSeconds)
+	}
+	if cc.ControlPlaneTimeoutSeconds != 600 {
+		t.Errorf("expected a control plane timeout of 600 seconds, but got %d", cc.ControlPlaneTimeoutSeconds)
+	}
+	if cc.CNITimeoutSeconds != 180 {
+		t.Errorf("expected a CNI timeout of 180 seconds, but got %d", cc.CNITimeoutSeconds)
+	}
+	if cc.SystemPodsTimeoutSeconds != 900 {
+		t.Errorf("expected a system pods timeout of 900 seconds, but got %d", cc.SystemPodsTimeoutSeconds)
+	}
+}
@@ -0,0 +1,18 @@
+package install
+
+import (
+	"errors"
+	"strings"
+)
+
+func (options *EtcdOptions) validate() (bool, []error) {
+	v := newValidator()
+	if options.AutoCompactionRetentionHours < 0 {
+		v.addError(errors.New("Etcd auto compaction retention cannot be negative"))
+	}
+	// the schedule is written to a systemd timer unit
+	if options.DefragSchedule != "" && (strings.TrimSpace(options.DefragSchedule) == "" || strings.ContainsAny(options.DefragSchedule, "\n\r")) {
+		v.addError(errors.New("Etcd defrag schedule must be a single line systemd calendar event"))
+	}
+	return v.valid()
+}
@@ -0,0 +1,41 @@
+package install
+
+import "testing"
+
+func TestValidateEtcdOptions(t *testing.T) {
+	tests := []struct {
+		opts  EtcdOptions
+		valid bool
+	}{
+		{
+			opts:  EtcdOptions{},
+			valid: true,
+		},
+		{
+			opts:  EtcdOptions{AutoCompactionRetentionHours: 1, DefragSchedule: "Sun *-*-* 03:00:00"},
+			valid: true,
+		},
+		{
+			opts:  EtcdOptions{DefragSchedule: "weekly"},
+			valid: true,
+		},
+		{
+			opts:  EtcdOptions{AutoCompactionRetentionHours: -1},
+			valid: false,
+		},
+		{
+			opts:  EtcdOptions{DefragSchedule: "  "},
+			valid: false,
+		},
+		{
+			opts:  EtcdOptions{DefragSchedule: "weekly\nExecStart=/bin/sh"},
+			valid: false,
+		},
+	}
+	for i, test := range tests {
+		ok, errs := test.opts.validate()
+		if ok != test.valid {
+			t.Errorf("test %d: expected valid to be %v, but got %v: %v", i, test.valid, ok, errs)
+		}
+	}
+}
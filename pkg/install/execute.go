@@ -694,13 +694,25 @@ func (ae *ansibleExecutor) buildClusterCatalog(p *Plan) (*ansible.ClusterCatalog
 		HTTPProxy:                     p.Cluster.Networking.HTTPProxy,
 		HTTPSProxy:                    p.Cluster.Networking.HTTPSProxy,
 		TargetVersion:                 KismaticVersion.String(),
-		APIServerOptions:              p.Cluster.APIServerOptions.Overrides,
+		APIServerOptions:              p.Cluster.APIServerOptions.apiServerOverrides(),
 		KubeControllerManagerOptions:  p.Cluster.KubeControllerManagerOptions.Overrides,
 		KubeSchedulerOptions:          p.Cluster.KubeSchedulerOptions.Overrides,
 		KubeProxyOptions:              p.Cluster.KubeProxyOptions.Overrides,
-		KubeletOptions:                p.Cluster.KubeletOptions.Overrides,
+		KubeProxyMode:                 p.Cluster.KubeProxyOptions.proxyMode(),
+		KubeletOptions:                p.Cluster.KubeletOptions.kubeletOverrides(),
 	}
 
+	cc.AllowWorkloadsOnMasters = p.Master.AllowWorkloads
+	cc.WorkerStartupTaint = p.Cluster.WorkerStartupTaint
+	cc.NodeCIDRMaskSize = p.Cluster.Networking.NodeCIDRMaskSize
+	cc.ClusterDNSDomain = p.Cluster.Networking.ClusterDNSDomain()
+	cc.EtcdAutoCompactionRetentionHours = p.Cluster.EtcdOptions.AutoCompactionRetentionHours
+	cc.EtcdDefragSchedule = p.Cluster.EtcdOptions.DefragSchedule
+	cc.EtcdTimeoutSeconds = timeoutSeconds(p.Cluster.Timeouts.Etcd, defaultEtcdTimeout)
+	cc.ControlPlaneTimeoutSeconds = timeoutSeconds(p.Cluster.Timeouts.ControlPlane, defaultControlPlaneTimeout)
+	cc.CNITimeoutSeconds = timeoutSeconds(p.Cluster.Timeouts.CNI, defaultCNITimeout)
+	cc.SystemPodsTimeoutSeconds = timeoutSeconds(p.Cluster.Timeouts.SystemPods, defaultSystemPodsTimeout)
+
 	// set versions
 	cc.Versions.Kubernetes = p.Cluster.Version
 	cc.Versions.KubernetesYum = p.Cluster.Version[1:] + "-0"
@@ -757,12 +769,18 @@ func (ae *ansibleExecutor) buildClusterCatalog(p *Plan) (*ansible.ClusterCatalog
 		ThinpoolAutoextendThreshold: p.Docker.Storage.DirectLVMBlockDevice.ThinpoolAutoextendThreshold,
 		ThinpoolAutoextendPercent:   p.Docker.Storage.DirectLVMBlockDevice.ThinpoolAutoextendPercent,
 	}
+	cc.Docker.RegistryMirrors = []string{}
+	for _, m := range p.Docker.RegistryMirrors {
+		cc.Docker.RegistryMirrors = append(cc.Docker.RegistryMirrors, normalizeRegistryMirror(m))
+	}
 
 	if p.Ingress.Nodes != nil && len(p.Ingress.Nodes) > 0 {
 		cc.EnableConfigureIngress = true
 	} else {
 		cc.EnableConfigureIngress = false
 	}
+	cc.IngressDefaultCertificate = p.Cluster.Ingress.DefaultCertificateFile
+	cc.IngressDefaultKey = p.Cluster.Ingress.DefaultKeyFile
 
 	if p.NFS != nil {
 		for _, n := range p.NFS.Volumes {
@@ -773,11 +791,27 @@ func (ae *ansibleExecutor) buildClusterCatalog(p *Plan) (*ansible.ClusterCatalog
 		}
 	}
 
+	for _, rd := range p.Cluster.ResourceDefaults {
+		cc.ResourceDefaults = append(cc.ResourceDefaults, ansible.ResourceDefaults{
+			Namespace:       rd.Namespace,
+			DefaultRequests: rd.DefaultRequests,
+			DefaultLimits:   rd.DefaultLimits,
+			Quota:           rd.Quota,
+		})
+	}
+
 	cc.EnableGluster = p.Storage.Nodes != nil && len(p.Storage.Nodes) > 0
 
 	cc.CloudProvider = p.Cluster.CloudProvider.Provider
 	cc.CloudConfig = p.Cluster.CloudProvider.Config
 
+	oidc := p.Cluster.APIServerOptions.OIDC
+	cc.OIDC.IssuerURL = oidc.IssuerURL
+	cc.OIDC.ClientID = oidc.ClientID
+	cc.OIDC.UsernameClaim = oidc.UsernameClaim
+	cc.OIDC.GroupsClaim = oidc.GroupsClaim
+	cc.OIDC.CAFile = oidc.CAFile
+
 	// additional files
 	for _, n := range p.AdditionalFiles {
 		cc.AdditionalFiles = append(cc.AdditionalFiles, ansible.AdditionalFile{
@@ -811,6 +845,9 @@ func (ae *ansibleExecutor) buildClusterCatalog(p *Plan) (*ansible.ClusterCatalog
 	cc.DNS.Enabled = !p.AddOns.DNS.Disable
 	cc.DNS.Provider = p.AddOns.DNS.Provider
 	cc.DNS.Options.Replicas = p.AddOns.DNS.Options.Replicas
+	cc.DNS.Options.CacheTTL = p.AddOns.DNS.Options.CacheTTL
+	cc.DNS.Options.Upstreams = p.AddOns.DNS.Options.Upstreams
+	cc.DNS.Options.Autopath = p.AddOns.DNS.Options.Autopath
 
 	// heapster
 	if p.AddOns.HeapsterMonitoring != nil && !p.AddOns.HeapsterMonitoring.Disable {
@@ -823,6 +860,8 @@ func (ae *ansibleExecutor) buildClusterCatalog(p *Plan) (*ansible.ClusterCatalog
 
 	// metrics-server
 	cc.MetricsServer.Enabled = !p.AddOns.MetricsServer.Disable
+	cc.MetricsServer.Options.KubeletInsecureTLS = p.AddOns.MetricsServer.Options.KubeletInsecureTLS
+	cc.MetricsServer.Options.MetricResolution = p.AddOns.MetricsServer.Options.MetricResolution
 
 	// dashboard
 	cc.Dashboard.Enabled = !p.AddOns.Dashboard.Disable
@@ -869,7 +908,7 @@ func (ae *ansibleExecutor) buildClusterCatalog(p *Plan) (*ansible.ClusterCatalog
 	// setup kubelet node overrides
 	cc.KubeletNodeOptions = make(map[string]map[string]string)
 	for _, n := range p.GetUniqueNodes() {
-		cc.KubeletNodeOptions[n.Host] = n.KubeletOptions.Overrides
+		cc.KubeletNodeOptions[n.Host] = n.KubeletOptions.kubeletOverrides()
 	}
 
 	return &cc, nil
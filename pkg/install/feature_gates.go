@@ -0,0 +1,138 @@
+package install
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// featureGate is a Kubernetes feature gate and the versions that recognize it.
+// A zero addedIn means that every supported version recognizes the gate, and a
+// zero removedIn means that the gate has not been removed.
+type featureGate struct {
+	addedIn   semver.Version
+	removedIn semver.Version
+}
+
+// the feature gates recognized by the Kubernetes components. Add the new gates
+// when supporting a new Kubernetes version, and set removedIn on the ones that
+// it no longer recognizes.
+var featureGates = map[string]featureGate{
+	"APIListChunking":                         {},
+	"APIResponseCompression":                  {},
+	"Accelerators":                            {removedIn: semver.Version{Major: 1, Minor: 11}},
+	"AdvancedAuditing":                        {},
+	"AffinityInAnnotations":                   {removedIn: semver.Version{Major: 1, Minor: 8}},
+	"AllAlpha":                                {},
+	"AppArmor":                                {},
+	"BlockVolume":                             {},
+	"CPUManager":                              {},
+	"CRIContainerLogRotation":                 {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"CSIPersistentVolume":                     {},
+	"CustomPodDNS":                            {},
+	"CustomResourceSubresources":              {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"CustomResourceValidation":                {},
+	"DebugContainers":                         {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"DevicePlugins":                           {},
+	"DynamicKubeletConfig":                    {},
+	"EnableEquivalenceClassCache":             {},
+	"ExpandPersistentVolumes":                 {},
+	"ExperimentalCriticalPodAnnotation":       {},
+	"ExperimentalHostUserNamespaceDefaulting": {},
+	"GCERegionalPersistentDisk":               {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"HugePages":                               {},
+	"HyperVContainer":                         {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"Initializers":                            {},
+	"LocalStorageCapacityIsolation":           {},
+	"MountContainers":                         {},
+	"MountPropagation":                        {},
+	"PVCProtection":                           {removedIn: semver.Version{Major: 1, Minor: 11}},
+	"PersistentLocalVolumes":                  {},
+	"PodPriority":                             {},
+	"PodShareProcessNamespace":                {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"ReadOnlyAPIDataVolumes":                  {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"ResourceLimitsPriorityFunction":          {},
+	"RotateKubeletClientCertificate":          {},
+	"RotateKubeletServerCertificate":          {},
+	"RunAsGroup":                              {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"ServiceNodeExclusion":                    {},
+	"StorageObjectInUseProtection":            {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"StreamingProxyRedirects":                 {},
+	"SupportIPVSProxyMode":                    {},
+	"SupportPodPidsLimit":                     {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"TaintBasedEvictions":                     {},
+	"TaintNodesByCondition":                   {},
+	"TokenRequest":                            {addedIn: semver.Version{Major: 1, Minor: 10}},
+	"VolumeScheduling":                        {},
+	"VolumeSubpath":                           {addedIn: semver.Version{Major: 1, Minor: 10}},
+}
+
+type featureGatesCompatibility struct {
+	Plan *Plan
+}
+
+// verify that the feature gates set through the option overrides are
+// recognized by the Kubernetes version of the cluster
+func (f *featureGatesCompatibility) validate() (bool, []error) {
+	v := newValidator()
+	version := f.Plan.Cluster.Version
+	if version == "" {
+		version = kubernetesVersionString
+	}
+	target, err := parseVersion(version)
+	if err != nil {
+		// the version is validated with the rest of the cluster config
+		return v.valid()
+	}
+	minor := semver.Version{Major: target.Major, Minor: target.Minor}
+	overrides := componentOverrides(f.Plan)
+	components := make([]string, 0, len(overrides))
+	for c := range overrides {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+	for _, c := range components {
+		for _, source := range overrides[c] {
+			value, found := source.overrides["feature-gates"]
+			if !found {
+				continue
+			}
+			for _, err := range validateFeatureGates(value, minor) {
+				v.addError(fmt.Errorf("Invalid %s feature gates in %s: %v", c, source.name, err))
+			}
+		}
+	}
+	return v.valid()
+}
+
+func validateFeatureGates(value string, version semver.Version) []error {
+	var errs []error
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		kv := strings.Split(s, "=")
+		if len(kv) != 2 {
+			errs = append(errs, fmt.Errorf("%q must be in the form Gate=true|false", s))
+			continue
+		}
+		name := strings.TrimSpace(kv[0])
+		if _, err := strconv.ParseBool(strings.TrimSpace(kv[1])); err != nil {
+			errs = append(errs, fmt.Errorf("the value of feature gate %q must be true or false", name))
+		}
+		gate, found := featureGates[name]
+		switch {
+		case !found:
+			errs = append(errs, fmt.Errorf("%q is not a known feature gate", name))
+		case version.LT(gate.addedIn):
+			errs = append(errs, fmt.Errorf("feature gate %q requires Kubernetes v%d.%d or newer", name, gate.addedIn.Major, gate.addedIn.Minor))
+		case gate.removedIn.Major > 0 && version.GE(gate.removedIn):
+			errs = append(errs, fmt.Errorf("feature gate %q was removed in Kubernetes v%d.%d", name, gate.removedIn.Major, gate.removedIn.Minor))
+		}
+	}
+	return errs
+}
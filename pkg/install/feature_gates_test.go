@@ -0,0 +1,56 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+)
+
+func TestValidateFeatureGates(t *testing.T) {
+	v110 := semver.Version{Major: 1, Minor: 10}
+	v19 := semver.Version{Major: 1, Minor: 9}
+	v111 := semver.Version{Major: 1, Minor: 11}
+	tests := []struct {
+		value   string
+		version semver.Version
+		errs    int
+	}{
+		{value: "PodPriority=true", version: v110},
+		{value: "PodPriority=true, RunAsGroup=false,", version: v110},
+		{value: "RunAsGroup=true", version: v19, errs: 1},
+		{value: "Accelerators=true", version: v110},
+		{value: "Accelerators=true", version: v111, errs: 1},
+		{value: "AffinityInAnnotations=true", version: v110, errs: 1},
+		{value: "NoSuchGate=true", version: v110, errs: 1},
+		{value: "PodPriority", version: v110, errs: 1},
+		{value: "PodPriority=yes", version: v110, errs: 1},
+		{value: "PodPriority=maybe,NoSuchGate=true", version: v110, errs: 2},
+	}
+	for _, test := range tests {
+		errs := validateFeatureGates(test.value, test.version)
+		if len(errs) != test.errs {
+			t.Errorf("%q on v%s: expected %d errors, got %v", test.value, test.version, test.errs, errs)
+		}
+	}
+}
+
+func TestFeatureGatesCompatibility(t *testing.T) {
+	p := validPlan()
+	p.Cluster.Version = "v1.10.5"
+	p.Cluster.APIServerOptions.Overrides = map[string]string{"feature-gates": "PodPriority=true"}
+	p.Cluster.KubeletOptions.Overrides = map[string]string{"feature-gates": "PodPriority=true"}
+	fg := featureGatesCompatibility{Plan: &p}
+	if ok, errs := fg.validate(); !ok {
+		t.Errorf("expected the feature gates to be valid, got %v", errs)
+	}
+
+	p.Worker.Nodes[0].KubeletOptions.Overrides = map[string]string{"feature-gates": "NoSuchGate=true"}
+	ok, errs := fg.validate()
+	if ok {
+		t.Fatalf("expected an unknown feature gate in the node kubelet options to be invalid")
+	}
+	expected := `Invalid kubelet feature gates in the kubelet options of node "worker01": "NoSuchGate" is not a known feature gate`
+	if len(errs) != 1 || errs[0].Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, errs)
+	}
+}
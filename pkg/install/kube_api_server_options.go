@@ -1,8 +1,13 @@
 package install
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
 	"strings"
+
+	"github.com/apprenda/kismatic/pkg/util"
 )
 
 var kubeAPIServerProtectedOptions = []string{
@@ -22,8 +27,80 @@ var kubeAPIServerProtectedOptions = []string{
 	"tls-private-key-file",
 }
 
+// the admission plugins enabled by default, as set in the
+// kubernetes_api_server_option_defaults of the ansible group vars
+var defaultAdmissionPlugins = []string{
+	"NamespaceLifecycle",
+	"LimitRanger",
+	"ServiceAccount",
+	"NodeRestriction",
+	"PersistentVolumeLabel",
+	"DefaultStorageClass",
+	"DefaultTolerationSeconds",
+	"MutatingAdmissionWebhook",
+	"ValidatingAdmissionWebhook",
+	"ResourceQuota",
+}
+
+// the admission plugins known to the supported Kubernetes version
+var knownAdmissionPlugins = []string{
+	"AlwaysAdmit",
+	"AlwaysDeny",
+	"AlwaysPullImages",
+	"DefaultStorageClass",
+	"DefaultTolerationSeconds",
+	"DenyEscalatingExec",
+	"DenyExecOnPrivileged",
+	"EventRateLimit",
+	"ExtendedResourceToleration",
+	"ImagePolicyWebhook",
+	"Initializers",
+	"LimitPodHardAntiAffinityTopology",
+	"LimitRanger",
+	"MutatingAdmissionWebhook",
+	"NamespaceAutoProvision",
+	"NamespaceExists",
+	"NamespaceLifecycle",
+	"NodeRestriction",
+	"OwnerReferencesPermissionEnforcement",
+	"PersistentVolumeClaimResize",
+	"PersistentVolumeLabel",
+	"PodNodeSelector",
+	"PodPreset",
+	"PodSecurityPolicy",
+	"PodTolerationRestriction",
+	"Priority",
+	"ResourceQuota",
+	"SecurityContextDeny",
+	"ServiceAccount",
+	"StorageObjectInUseProtection",
+	"ValidatingAdmissionWebhook",
+}
+
 func (options *APIServerOptions) validate() (bool, []error) {
 	v := newValidator()
+	v.validateWithErrPrefix("OIDC", &options.OIDC)
+	v.validateWithErrPrefix("Admission plugins", &options.AdmissionPlugins)
+	v.validateWithErrPrefix("TLS", &options.TLS)
+	if options.AdmissionPlugins.configured() {
+		for _, opt := range []string{"enable-admission-plugins", "disable-admission-plugins"} {
+			if _, found := options.Overrides[opt]; found {
+				v.addError(fmt.Errorf("Kube ApiServer Option %q cannot be overridden when admission plugins are configured", opt))
+			}
+		}
+	}
+	for _, opt := range options.TLS.options() {
+		if _, found := options.Overrides[opt]; found {
+			v.addError(fmt.Errorf("Kube ApiServer Option %q cannot be overridden when it is set in the TLS options", opt))
+		}
+	}
+	if options.OIDC.IssuerURL != "" {
+		for opt := range options.Overrides {
+			if strings.HasPrefix(opt, "oidc-") {
+				v.addError(fmt.Errorf("Kube ApiServer Option %q cannot be overridden when OIDC is configured", opt))
+			}
+		}
+	}
 	overrides := make([]string, 0)
 	for _, protectedOption := range kubeAPIServerProtectedOptions {
 		_, found := options.Overrides[protectedOption]
@@ -38,3 +115,162 @@ func (options *APIServerOptions) validate() (bool, []error) {
 
 	return v.valid()
 }
+
+// apiServerOverrides returns the option overrides, including the options
+// that are set through dedicated fields
+func (options APIServerOptions) apiServerOverrides() map[string]string {
+	if !options.AdmissionPlugins.configured() && len(options.TLS.options()) == 0 {
+		return options.Overrides
+	}
+	overrides := map[string]string{}
+	for k, v := range options.Overrides {
+		overrides[k] = v
+	}
+	if options.AdmissionPlugins.configured() {
+		overrides["enable-admission-plugins"] = strings.Join(options.AdmissionPlugins.enabled(), ",")
+		if len(options.AdmissionPlugins.Disable) > 0 {
+			overrides["disable-admission-plugins"] = strings.Join(options.AdmissionPlugins.Disable, ",")
+		}
+	}
+	if options.TLS.MinVersion != "" {
+		overrides["tls-min-version"] = options.TLS.MinVersion
+	}
+	if len(options.TLS.CipherSuites) > 0 {
+		overrides["tls-cipher-suites"] = strings.Join(options.TLS.CipherSuites, ",")
+	}
+	return overrides
+}
+
+func (ap *AdmissionPlugins) validate() (bool, []error) {
+	v := newValidator()
+	for _, p := range append(append([]string{}, ap.Enable...), ap.Disable...) {
+		if !util.Contains(p, knownAdmissionPlugins) {
+			v.addError(fmt.Errorf("%q is not a known admission plugin", p))
+		}
+	}
+	for _, p := range ap.Enable {
+		if util.Contains(p, ap.Disable) {
+			v.addError(fmt.Errorf("Admission plugin %q cannot be both enabled and disabled", p))
+		}
+	}
+	if util.Contains("NodeRestriction", ap.Disable) && !ap.AllowDisablingNodeRestriction {
+		v.addError(errors.New("Admission plugin \"NodeRestriction\" can only be disabled when allow_disabling_node_restriction is set"))
+	}
+	return v.valid()
+}
+
+func (ap AdmissionPlugins) configured() bool {
+	return len(ap.Enable) > 0 || len(ap.Disable) > 0
+}
+
+// enabled returns the default admission plugins and the ones that are
+// enabled in the plan, without the ones that are disabled
+func (ap AdmissionPlugins) enabled() []string {
+	var plugins []string
+	for _, p := range append(append([]string{}, defaultAdmissionPlugins...), ap.Enable...) {
+		if !util.Contains(p, ap.Disable) && !util.Contains(p, plugins) {
+			plugins = append(plugins, p)
+		}
+	}
+	return plugins
+}
+
+func (o *OIDCOptions) validate() (bool, []error) {
+	v := newValidator()
+	if o.IssuerURL == "" {
+		if o.ClientID != "" || o.UsernameClaim != "" || o.GroupsClaim != "" || o.CAFile != "" {
+			v.addError(errors.New("Issuer URL is required when other OIDC options are set"))
+		}
+		return v.valid()
+	}
+	u, err := url.Parse(o.IssuerURL)
+	switch {
+	case err != nil:
+		v.addError(fmt.Errorf("Issuer URL %q is not a valid URL: %v", o.IssuerURL, err))
+	case u.Scheme != "https" || u.Host == "":
+		v.addError(fmt.Errorf("Issuer URL %q must be an https URL", o.IssuerURL))
+	case u.RawQuery != "" || u.Fragment != "":
+		v.addError(fmt.Errorf("Issuer URL %q cannot include a query or fragment", o.IssuerURL))
+	}
+	if o.ClientID == "" {
+		v.addError(errors.New("Client ID is required when the issuer URL is set"))
+	}
+	if o.CAFile != "" {
+		if _, err := os.Stat(o.CAFile); os.IsNotExist(err) {
+			v.addError(fmt.Errorf("CA file was not found at %q", o.CAFile))
+		}
+	}
+	return v.valid()
+}
+
+// the TLS versions and cipher suites accepted by the tls-min-version and
+// tls-cipher-suites flags of the supported Kubernetes version
+var (
+	tlsVersions = []string{"VersionTLS10", "VersionTLS11", "VersionTLS12"}
+
+	tlsCipherSuites = []string{
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305",
+		"TLS_ECDHE_ECDSA_WITH_RC4_128_SHA",
+		"TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA",
+		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
+		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305",
+		"TLS_ECDHE_RSA_WITH_RC4_128_SHA",
+		"TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+		"TLS_RSA_WITH_AES_128_CBC_SHA",
+		"TLS_RSA_WITH_AES_128_CBC_SHA256",
+		"TLS_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_RSA_WITH_AES_256_CBC_SHA",
+		"TLS_RSA_WITH_AES_256_GCM_SHA384",
+		"TLS_RSA_WITH_RC4_128_SHA",
+	}
+
+	// the API server serves HTTP/2, which fails to start without one of these
+	http2CipherSuites = []string{
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	}
+)
+
+func (t *TLSOptions) validate() (bool, []error) {
+	v := newValidator()
+	if t.MinVersion != "" && !util.Contains(t.MinVersion, tlsVersions) {
+		v.addError(fmt.Errorf("Minimum version %q is not valid. Options are %v", t.MinVersion, tlsVersions))
+	}
+	if len(t.CipherSuites) == 0 {
+		return v.valid()
+	}
+	http2 := false
+	for _, c := range t.CipherSuites {
+		if !util.Contains(c, tlsCipherSuites) {
+			v.addError(fmt.Errorf("%q is not a supported cipher suite", c))
+		}
+		if util.Contains(c, http2CipherSuites) {
+			http2 = true
+		}
+	}
+	if !http2 {
+		v.addError(fmt.Errorf("Cipher suites must include one of %v, as they are required by HTTP/2", http2CipherSuites))
+	}
+	return v.valid()
+}
+
+// options returns the flags that are set by the TLS options
+func (t TLSOptions) options() []string {
+	var opts []string
+	if t.MinVersion != "" {
+		opts = append(opts, "tls-min-version")
+	}
+	if len(t.CipherSuites) > 0 {
+		opts = append(opts, "tls-cipher-suites")
+	}
+	return opts
+}
@@ -65,6 +65,186 @@ func TestValidateKubeApiServerOptions(t *testing.T) {
 	}
 }
 
+func TestValidateKubeApiServerOIDCOptions(t *testing.T) {
+	tests := []struct {
+		opts  APIServerOptions
+		valid bool
+	}{
+		{
+			opts:  APIServerOptions{OIDC: OIDCOptions{IssuerURL: "https://accounts.example.com", ClientID: "kubernetes"}},
+			valid: true,
+		},
+		{
+			opts: APIServerOptions{
+				OIDC:      OIDCOptions{IssuerURL: "https://accounts.example.com", ClientID: "kubernetes", UsernameClaim: "email", GroupsClaim: "groups"},
+				Overrides: map[string]string{"oidc-username-prefix": "oidc:"},
+			},
+			valid: false,
+		},
+		{
+			opts:  APIServerOptions{OIDC: OIDCOptions{IssuerURL: "http://accounts.example.com", ClientID: "kubernetes"}},
+			valid: false,
+		},
+		{
+			opts:  APIServerOptions{OIDC: OIDCOptions{IssuerURL: "accounts.example.com", ClientID: "kubernetes"}},
+			valid: false,
+		},
+		{
+			opts:  APIServerOptions{OIDC: OIDCOptions{IssuerURL: "https://accounts.example.com"}},
+			valid: false,
+		},
+		{
+			opts:  APIServerOptions{OIDC: OIDCOptions{ClientID: "kubernetes"}},
+			valid: false,
+		},
+		{
+			opts:  APIServerOptions{OIDC: OIDCOptions{IssuerURL: "https://accounts.example.com", ClientID: "kubernetes", CAFile: "/does/not/exist/ca.pem"}},
+			valid: false,
+		},
+		{
+			opts:  APIServerOptions{Overrides: map[string]string{"oidc-issuer-url": "https://accounts.example.com"}},
+			valid: true,
+		},
+	}
+	for i, test := range tests {
+		ok, errs := test.opts.validate()
+		if ok != test.valid {
+			t.Errorf("test %d: expect %t, but got %t: %v", i, test.valid, ok, errs)
+		}
+	}
+}
+
+func TestValidateKubeApiServerAdmissionPlugins(t *testing.T) {
+	tests := []struct {
+		plugins   AdmissionPlugins
+		overrides map[string]string
+		valid     bool
+	}{
+		{
+			plugins: AdmissionPlugins{Enable: []string{"AlwaysPullImages", "PodSecurityPolicy"}, Disable: []string{"DefaultStorageClass"}},
+			valid:   true,
+		},
+		{
+			plugins: AdmissionPlugins{Disable: []string{"NodeRestriction"}, AllowDisablingNodeRestriction: true},
+			valid:   true,
+		},
+		{
+			plugins: AdmissionPlugins{Disable: []string{"NodeRestriction"}},
+			valid:   false,
+		},
+		{
+			plugins: AdmissionPlugins{Enable: []string{"AlwaysPullImage"}},
+			valid:   false,
+		},
+		{
+			plugins: AdmissionPlugins{Enable: []string{"AlwaysPullImages"}, Disable: []string{"AlwaysPullImages"}},
+			valid:   false,
+		},
+		{
+			plugins:   AdmissionPlugins{Enable: []string{"AlwaysPullImages"}},
+			overrides: map[string]string{"enable-admission-plugins": "AlwaysPullImages"},
+			valid:     false,
+		},
+		{
+			overrides: map[string]string{"enable-admission-plugins": "AlwaysPullImages"},
+			valid:     true,
+		},
+	}
+	for i, test := range tests {
+		opts := APIServerOptions{AdmissionPlugins: test.plugins, Overrides: test.overrides}
+		ok, errs := opts.validate()
+		if ok != test.valid {
+			t.Errorf("test %d: expect %t, but got %t: %v", i, test.valid, ok, errs)
+		}
+	}
+}
+
+func TestAPIServerOverridesAdmissionPlugins(t *testing.T) {
+	opts := APIServerOptions{Overrides: map[string]string{"v": "3"}}
+	if !reflect.DeepEqual(opts.apiServerOverrides(), opts.Overrides) {
+		t.Errorf("expected the overrides to be unchanged, got %v", opts.apiServerOverrides())
+	}
+
+	opts.AdmissionPlugins = AdmissionPlugins{
+		Enable:  []string{"AlwaysPullImages", "ResourceQuota"},
+		Disable: []string{"DefaultStorageClass", "PersistentVolumeLabel", "DefaultTolerationSeconds"},
+	}
+	expected := map[string]string{
+		"v":                         "3",
+		"enable-admission-plugins":  "NamespaceLifecycle,LimitRanger,ServiceAccount,NodeRestriction,MutatingAdmissionWebhook,ValidatingAdmissionWebhook,ResourceQuota,AlwaysPullImages",
+		"disable-admission-plugins": "DefaultStorageClass,PersistentVolumeLabel,DefaultTolerationSeconds",
+	}
+	if got := opts.apiServerOverrides(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if _, found := opts.Overrides["enable-admission-plugins"]; found {
+		t.Errorf("expected the plan overrides not to be modified")
+	}
+}
+
+func TestValidateKubeApiServerTLSOptions(t *testing.T) {
+	tests := []struct {
+		tls       TLSOptions
+		overrides map[string]string
+		valid     bool
+	}{
+		{
+			tls:   TLSOptions{MinVersion: "VersionTLS12", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+			valid: true,
+		},
+		{
+			tls:   TLSOptions{MinVersion: "VersionTLS11"},
+			valid: true,
+		},
+		{
+			tls:   TLSOptions{MinVersion: "TLS1.2"},
+			valid: false,
+		},
+		{
+			tls:   TLSOptions{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_FOO"}},
+			valid: false,
+		},
+		{
+			tls:   TLSOptions{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+			valid: false,
+		},
+		{
+			tls:       TLSOptions{MinVersion: "VersionTLS12"},
+			overrides: map[string]string{"tls-min-version": "VersionTLS11"},
+			valid:     false,
+		},
+		{
+			overrides: map[string]string{"tls-min-version": "VersionTLS11"},
+			valid:     true,
+		},
+	}
+	for i, test := range tests {
+		opts := APIServerOptions{TLS: test.tls, Overrides: test.overrides}
+		ok, errs := opts.validate()
+		if ok != test.valid {
+			t.Errorf("test %d: expect %t, but got %t: %v", i, test.valid, ok, errs)
+		}
+	}
+}
+
+func TestAPIServerOverridesTLS(t *testing.T) {
+	opts := APIServerOptions{
+		TLS: TLSOptions{
+			MinVersion:   "VersionTLS12",
+			CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+		},
+		Overrides: map[string]string{"v": "3"},
+	}
+	expected := map[string]string{
+		"v":                 "3",
+		"tls-min-version":   "VersionTLS12",
+		"tls-cipher-suites": "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	}
+	if got := opts.apiServerOverrides(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
 func assertEqual(t *testing.T, a, b interface{}) {
 	if !reflect.DeepEqual(a, b) {
 		t.Errorf("%v != %v", a, b)
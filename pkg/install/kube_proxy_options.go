@@ -3,8 +3,14 @@ package install
 import (
 	"fmt"
 	"strings"
+
+	"github.com/apprenda/kismatic/pkg/util"
 )
 
+func kubeProxyModes() []string {
+	return []string{"iptables", "ipvs"}
+}
+
 var kubeProxyProtectedOptions = []string{
 	"cluster-cidr",
 	"hostname-override",
@@ -24,5 +30,26 @@ func (options *KubeProxyOptions) validate() (bool, []error) {
 		v.addError(fmt.Errorf("Kube Proxy Option(s) [%v] cannot be overridden", strings.Join(overrides, ", ")))
 	}
 
+	if options.Mode != "" {
+		if !util.Contains(options.Mode, kubeProxyModes()) {
+			v.addError(fmt.Errorf("Kube Proxy mode %q is not valid. Options are %v", options.Mode, kubeProxyModes()))
+		}
+		if _, found := options.Overrides["proxy-mode"]; found {
+			v.addError(fmt.Errorf("Kube Proxy mode cannot be set together with the %q option override", "proxy-mode"))
+		}
+	}
+
 	return v.valid()
 }
+
+// proxyMode returns the mode kube-proxy will run in, taking into account
+// the proxy-mode option override.
+func (options KubeProxyOptions) proxyMode() string {
+	if options.Mode != "" {
+		return options.Mode
+	}
+	if mode := options.Overrides["proxy-mode"]; mode != "" {
+		return mode
+	}
+	return "iptables"
+}
@@ -61,3 +61,61 @@ func TestValidateKubeProxyOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateKubeProxyMode(t *testing.T) {
+	tests := []struct {
+		opts  KubeProxyOptions
+		valid bool
+	}{
+		{
+			opts:  KubeProxyOptions{Mode: "iptables"},
+			valid: true,
+		},
+		{
+			opts:  KubeProxyOptions{Mode: "ipvs"},
+			valid: true,
+		},
+		{
+			opts:  KubeProxyOptions{Mode: "userspace"},
+			valid: false,
+		},
+		{
+			opts: KubeProxyOptions{
+				Mode:      "ipvs",
+				Overrides: map[string]string{"proxy-mode": "iptables"},
+			},
+			valid: false,
+		},
+	}
+	for i, test := range tests {
+		ok, errs := test.opts.validate()
+		if ok != test.valid {
+			t.Errorf("test %d: expected %t, but got %t: %v", i, test.valid, ok, errs)
+		}
+	}
+}
+
+func TestKubeProxyMode(t *testing.T) {
+	tests := []struct {
+		opts KubeProxyOptions
+		mode string
+	}{
+		{
+			opts: KubeProxyOptions{},
+			mode: "iptables",
+		},
+		{
+			opts: KubeProxyOptions{Mode: "ipvs"},
+			mode: "ipvs",
+		},
+		{
+			opts: KubeProxyOptions{Overrides: map[string]string{"proxy-mode": "ipvs"}},
+			mode: "ipvs",
+		},
+	}
+	for i, test := range tests {
+		if mode := test.opts.proxyMode(); mode != test.mode {
+			t.Errorf("test %d: expected mode %q, but got %q", i, test.mode, mode)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package install
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	kubeadmAPIVersion = "kubeadm.k8s.io/v1alpha1"
+	// the certificates are expected in the same location as on the master
+	// nodes installed by kismatic. kubeadm mounts this directory into the
+	// API server.
+	kubeadmCertsDir = "/etc/kubernetes/pki"
+)
+
+// kubeadmMasterConfiguration is the subset of kubeadm's MasterConfiguration
+// (kubeadm.k8s.io/v1alpha1, as consumed by kubeadm v1.10) that can be derived
+// from the plan.
+type kubeadmMasterConfiguration struct {
+	APIVersion                 string            `yaml:"apiVersion"`
+	Kind                       string            `yaml:"kind"`
+	KubernetesVersion          string            `yaml:"kubernetesVersion"`
+	API                        kubeadmAPI        `yaml:"api"`
+	Etcd                       kubeadmEtcd       `yaml:"etcd"`
+	Networking                 kubeadmNetworking `yaml:"networking"`
+	CloudProvider              string            `yaml:"cloudProvider,omitempty"`
+	APIServerCertSANs          []string          `yaml:"apiServerCertSANs,omitempty"`
+	APIServerExtraArgs         map[string]string `yaml:"apiServerExtraArgs,omitempty"`
+	ControllerManagerExtraArgs map[string]string `yaml:"controllerManagerExtraArgs,omitempty"`
+	SchedulerExtraArgs         map[string]string `yaml:"schedulerExtraArgs,omitempty"`
+	ImageRepository            string            `yaml:"imageRepository,omitempty"`
+	KubeProxy                  *kubeadmKubeProxy `yaml:"kubeProxy,omitempty"`
+}
+
+type kubeadmAPI struct {
+	AdvertiseAddress     string `yaml:"advertiseAddress,omitempty"`
+	ControlPlaneEndpoint string `yaml:"controlPlaneEndpoint,omitempty"`
+	BindPort             int    `yaml:"bindPort"`
+}
+
+type kubeadmEtcd struct {
+	Endpoints []string `yaml:"endpoints,omitempty"`
+	CAFile    string   `yaml:"caFile,omitempty"`
+	CertFile  string   `yaml:"certFile,omitempty"`
+	KeyFile   string   `yaml:"keyFile,omitempty"`
+}
+
+type kubeadmNetworking struct {
+	ServiceSubnet string `yaml:"serviceSubnet"`
+	PodSubnet     string `yaml:"podSubnet"`
+	DNSDomain     string `yaml:"dnsDomain"`
+}
+
+type kubeadmKubeProxy struct {
+	Config struct {
+		Mode string `yaml:"mode"`
+	} `yaml:"config"`
+}
+
+// KubeadmConfig translates the plan into a kubeadm MasterConfiguration that
+// can be passed to "kubeadm init --config". The translation covers the
+// Kubernetes version, the API server endpoint, the etcd endpoints, the
+// networking CIDRs, the API server certificate SANs, the API server options,
+// the component option overrides, the cloud provider, the image registry and
+// the kube-proxy mode.
+//
+// Plan fields that have no kubeadm equivalent are not included, such as the
+// add-ons, docker configuration, package installation settings, kubelet
+// option overrides, node labels and taints, NFS and storage configuration.
+// The generated config is preceded by a comment that lists the nodes to run
+// "kubeadm init" and "kubeadm join" on.
+func KubeadmConfig(p Plan) ([]byte, error) {
+	if len(p.Master.Nodes) == 0 {
+		return nil, fmt.Errorf("the plan does not contain any master nodes")
+	}
+	host, port, err := p.ClusterAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	c := kubeadmMasterConfiguration{
+		APIVersion:        kubeadmAPIVersion,
+		Kind:              "MasterConfiguration",
+		KubernetesVersion: p.Cluster.Version,
+		API: kubeadmAPI{
+			AdvertiseAddress:     nodeAddress(p.Master.Nodes[0]),
+			ControlPlaneEndpoint: host,
+			BindPort:             6443,
+		},
+		Networking: kubeadmNetworking{
+			ServiceSubnet: p.Cluster.Networking.ServiceCIDRBlock,
+			PodSubnet:     p.Cluster.Networking.PodCIDRBlock,
+			DNSDomain:     p.Cluster.Networking.ClusterDNSDomain(),
+		},
+		CloudProvider:              p.Cluster.CloudProvider.Provider,
+		APIServerExtraArgs:         kubeadmAPIServerArgs(p.Cluster.APIServerOptions),
+		ControllerManagerExtraArgs: p.Cluster.KubeControllerManagerOptions.Overrides,
+		SchedulerExtraArgs:         p.Cluster.KubeSchedulerOptions.Overrides,
+	}
+	if c.KubernetesVersion == "" {
+		c.KubernetesVersion = kubernetesVersionString
+	}
+	if mask := p.Cluster.Networking.NodeCIDRMaskSize; mask > 0 {
+		args := map[string]string{"node-cidr-mask-size": strconv.Itoa(mask)}
+		for k, v := range c.ControllerManagerExtraArgs {
+			args[k] = v
+		}
+		c.ControllerManagerExtraArgs = args
+	}
+	if port != "" && port != "6443" {
+		c.API.ControlPlaneEndpoint = host + ":" + port
+	}
+
+	for _, n := range p.Etcd.Nodes {
+		c.Etcd.Endpoints = append(c.Etcd.Endpoints, fmt.Sprintf("https://%s:2379", nodeAddress(n)))
+	}
+	c.Etcd.CAFile = filepath.Join(kubeadmCertsDir, "ca.pem")
+	c.Etcd.CertFile = filepath.Join(kubeadmCertsDir, "etcd-client.pem")
+	c.Etcd.KeyFile = filepath.Join(kubeadmCertsDir, "etcd-client-key.pem")
+
+	sans := []string{host}
+	for _, n := range p.Master.Nodes {
+		sans = append(sans, n.Host, n.IP)
+		if n.InternalIP != "" {
+			sans = append(sans, n.InternalIP)
+		}
+	}
+	for _, s := range strings.Split(p.Cluster.Certificates.APIServerCertExtraSANs, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			sans = append(sans, s)
+		}
+	}
+	for _, s := range sans {
+		if !contains(s, c.APIServerCertSANs) {
+			c.APIServerCertSANs = append(c.APIServerCertSANs, s)
+		}
+	}
+
+	// images are only pulled from the private registry in disconnected
+	// installations, where they are stored under their upstream name
+	if p.PrivateRegistryProvided() && p.Cluster.DisconnectedInstallation {
+		c.ImageRepository = p.DockerRegistry.Server + "/gcr.io/google-containers"
+	}
+	if mode := p.Cluster.KubeProxyOptions.proxyMode(); mode != "iptables" {
+		c.KubeProxy = &kubeadmKubeProxy{}
+		c.KubeProxy.Config.Mode = mode
+	}
+
+	y, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling kubeadm configuration: %v", err)
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Generated from the plan of cluster %q.\n", p.Cluster.Name)
+	fmt.Fprintf(&b, "# Run 'kubeadm init --config <this file>' on %s.\n", p.Master.Nodes[0].Host)
+	if joins := kubeadmJoinNodes(p); len(joins) > 0 {
+		fmt.Fprintf(&b, "# Run 'kubeadm join' on %s.\n", strings.Join(joins, ", "))
+	}
+	fmt.Fprintf(&b, "# Etcd must be set up before running kubeadm. Etcd nodes: %s.\n", strings.Join(nodeHosts(p.Etcd.Nodes), ", "))
+	fmt.Fprintf(&b, "# Copy ca.pem, etcd-client.pem and etcd-client-key.pem from the generated keys directory to %s on the master nodes.\n", kubeadmCertsDir)
+	if caFile := p.Cluster.APIServerOptions.OIDC.CAFile; caFile != "" {
+		fmt.Fprintf(&b, "# Copy the OIDC CA file %s to %s on the master nodes.\n", caFile, filepath.Join(kubeadmCertsDir, "oidc-ca.pem"))
+	}
+	b.Write(y)
+	return b.Bytes(), nil
+}
+
+// the API server flags that are set through the plan, including the ones that
+// are set through dedicated fields, such as OIDC, admission plugins and TLS
+func kubeadmAPIServerArgs(options APIServerOptions) map[string]string {
+	args := map[string]string{}
+	if oidc := options.OIDC; oidc.IssuerURL != "" {
+		args["oidc-issuer-url"] = oidc.IssuerURL
+		args["oidc-client-id"] = oidc.ClientID
+		if oidc.UsernameClaim != "" {
+			args["oidc-username-claim"] = oidc.UsernameClaim
+		}
+		if oidc.GroupsClaim != "" {
+			args["oidc-groups-claim"] = oidc.GroupsClaim
+		}
+		if oidc.CAFile != "" {
+			args["oidc-ca-file"] = filepath.Join(kubeadmCertsDir, "oidc-ca.pem")
+		}
+	}
+	for k, v := range options.apiServerOverrides() {
+		args[k] = v
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return args
+}
+
+// the nodes that run "kubeadm join", other than the first master
+func kubeadmJoinNodes(p Plan) []string {
+	var hosts []string
+	seen := map[string]bool{p.Master.Nodes[0].Host: true}
+	nodes := append([]Node{}, p.Master.Nodes[1:]...)
+	nodes = append(nodes, p.Worker.Nodes...)
+	nodes = append(nodes, p.Ingress.Nodes...)
+	nodes = append(nodes, p.Storage.Nodes...)
+	for _, n := range nodes {
+		if !seen[n.Host] {
+			seen[n.Host] = true
+			hosts = append(hosts, n.Host)
+		}
+	}
+	return hosts
+}
+
+func nodeHosts(nodes []Node) []string {
+	var hosts []string
+	for _, n := range nodes {
+		hosts = append(hosts, n.Host)
+	}
+	return hosts
+}
+
+// returns the address that other cluster nodes use to reach the node
+func nodeAddress(n Node) string {
+	if n.InternalIP != "" {
+		return n.InternalIP
+	}
+	return n.IP
+}
@@ -0,0 +1,123 @@
+package install
+
+import (
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestKubeadmConfig(t *testing.T) {
+	p := Plan{
+		Cluster: Cluster{
+			Name:                     "test",
+			Version:                  "v1.10.5",
+			DisconnectedInstallation: true,
+			Networking: NetworkConfig{
+				PodCIDRBlock:     "172.16.0.0/16",
+				ServiceCIDRBlock: "172.20.0.0/16",
+				NodeCIDRMaskSize: 25,
+			},
+			Certificates: CertsConfig{
+				APIServerCertExtraSANs: "api.example.com, 10.1.2.3",
+			},
+			APIServerOptions: APIServerOptions{
+				Overrides: map[string]string{"v": "3"},
+			},
+			KubeProxyOptions: KubeProxyOptions{Mode: "ipvs"},
+			CloudProvider:    CloudProvider{Provider: "aws"},
+		},
+		DockerRegistry: DockerRegistry{Server: "registry.local:5000"},
+		Etcd: NodeGroup{
+			Nodes: []Node{{Host: "etcd01", IP: "10.0.0.1", InternalIP: "192.168.0.1"}},
+		},
+		Master: MasterNodeGroup{
+			LoadBalancer: "lb.example.com:6443",
+			Nodes: []Node{
+				{Host: "master01", IP: "10.0.0.2"},
+				{Host: "master02", IP: "10.0.0.3"},
+			},
+		},
+		Worker: NodeGroup{
+			Nodes: []Node{{Host: "worker01", IP: "10.0.0.4"}, {Host: "master02", IP: "10.0.0.3"}},
+		},
+	}
+	b, err := KubeadmConfig(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(b), "# Run 'kubeadm join' on master02, worker01.") {
+		t.Errorf("expected the join nodes to be listed, but got:\n%s", b)
+	}
+	c := kubeadmMasterConfiguration{}
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		t.Fatalf("error unmarshaling generated config: %v", err)
+	}
+	assertEqual(t, c.APIVersion, "kubeadm.k8s.io/v1alpha1")
+	assertEqual(t, c.Kind, "MasterConfiguration")
+	assertEqual(t, c.KubernetesVersion, "v1.10.5")
+	assertEqual(t, c.API.AdvertiseAddress, "10.0.0.2")
+	assertEqual(t, c.API.ControlPlaneEndpoint, "lb.example.com")
+	assertEqual(t, c.Etcd.Endpoints, []string{"https://192.168.0.1:2379"})
+	assertEqual(t, c.Etcd.CAFile, "/etc/kubernetes/pki/ca.pem")
+	assertEqual(t, c.Etcd.CertFile, "/etc/kubernetes/pki/etcd-client.pem")
+	assertEqual(t, c.Etcd.KeyFile, "/etc/kubernetes/pki/etcd-client-key.pem")
+	assertEqual(t, c.Networking.PodSubnet, "172.16.0.0/16")
+	assertEqual(t, c.Networking.ServiceSubnet, "172.20.0.0/16")
+	assertEqual(t, c.APIServerCertSANs, []string{"lb.example.com", "master01", "10.0.0.2", "master02", "10.0.0.3", "api.example.com", "10.1.2.3"})
+	assertEqual(t, c.APIServerExtraArgs, map[string]string{"v": "3"})
+	assertEqual(t, c.ControllerManagerExtraArgs, map[string]string{"node-cidr-mask-size": "25"})
+	assertEqual(t, c.CloudProvider, "aws")
+	assertEqual(t, c.ImageRepository, "registry.local:5000/gcr.io/google-containers")
+	if c.KubeProxy == nil || c.KubeProxy.Config.Mode != "ipvs" {
+		t.Errorf("expected kube-proxy mode to be ipvs, but got %+v", c.KubeProxy)
+	}
+}
+
+func TestKubeadmConfigAPIServerOptions(t *testing.T) {
+	p := Plan{
+		Cluster: Cluster{
+			Name: "test",
+			APIServerOptions: APIServerOptions{
+				OIDC: OIDCOptions{
+					IssuerURL:   "https://accounts.example.com",
+					ClientID:    "kubernetes",
+					GroupsClaim: "groups",
+					CAFile:      "/home/user/oidc-ca.pem",
+				},
+				AdmissionPlugins: AdmissionPlugins{Enable: []string{"AlwaysPullImages"}},
+				TLS:              TLSOptions{MinVersion: "VersionTLS12"},
+				Overrides:        map[string]string{"v": "3"},
+			},
+		},
+		Etcd:   NodeGroup{Nodes: []Node{{Host: "etcd01", IP: "10.0.0.1"}}},
+		Master: MasterNodeGroup{LoadBalancer: "10.0.0.2:6443", Nodes: []Node{{Host: "master01", IP: "10.0.0.2"}}},
+	}
+	b, err := KubeadmConfig(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(b), "# Copy the OIDC CA file /home/user/oidc-ca.pem to /etc/kubernetes/pki/oidc-ca.pem on the master nodes.") {
+		t.Errorf("expected the OIDC CA file to be listed, but got:\n%s", b)
+	}
+	c := kubeadmMasterConfiguration{}
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		t.Fatalf("error unmarshaling generated config: %v", err)
+	}
+	expected := map[string]string{
+		"v":                        "3",
+		"oidc-issuer-url":          "https://accounts.example.com",
+		"oidc-client-id":           "kubernetes",
+		"oidc-groups-claim":        "groups",
+		"oidc-ca-file":             "/etc/kubernetes/pki/oidc-ca.pem",
+		"enable-admission-plugins": strings.Join(append(append([]string{}, defaultAdmissionPlugins...), "AlwaysPullImages"), ","),
+		"tls-min-version":          "VersionTLS12",
+	}
+	assertEqual(t, c.APIServerExtraArgs, expected)
+}
+
+func TestKubeadmConfigNoMasters(t *testing.T) {
+	if _, err := KubeadmConfig(Plan{}); err == nil {
+		t.Error("expected an error when the plan has no masters")
+	}
+}
@@ -2,6 +2,7 @@ package install
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -37,5 +38,26 @@ func (options *KubeletOptions) validate() (bool, []error) {
 		v.addError(fmt.Errorf("Kubelet Option(s) [%v] cannot be overridden", strings.Join(overrides, ", ")))
 	}
 
+	if options.MaxPods < 0 {
+		v.addError(fmt.Errorf("Kubelet max pods %d is not valid, must be greater than 0", options.MaxPods))
+	}
+	if _, found := options.Overrides["max-pods"]; found && options.MaxPods != 0 {
+		v.addError(fmt.Errorf("Kubelet max pods cannot be set together with the %q option override", "max-pods"))
+	}
+
 	return v.valid()
 }
+
+// kubeletOverrides returns the option overrides, including the options that
+// are set through dedicated fields
+func (options KubeletOptions) kubeletOverrides() map[string]string {
+	if options.MaxPods == 0 {
+		return options.Overrides
+	}
+	overrides := map[string]string{}
+	for k, v := range options.Overrides {
+		overrides[k] = v
+	}
+	overrides["max-pods"] = strconv.Itoa(options.MaxPods)
+	return overrides
+}
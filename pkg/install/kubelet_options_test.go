@@ -0,0 +1,40 @@
+package install
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKubeletOptionsMaxPods(t *testing.T) {
+	tests := []struct {
+		options KubeletOptions
+		valid   bool
+	}{
+		{options: KubeletOptions{}, valid: true},
+		{options: KubeletOptions{MaxPods: 250}, valid: true},
+		{options: KubeletOptions{MaxPods: -1}, valid: false},
+		{options: KubeletOptions{MaxPods: 250, Overrides: map[string]string{"max-pods": "250"}}, valid: false},
+		{options: KubeletOptions{Overrides: map[string]string{"max-pods": "250"}}, valid: true},
+	}
+	for i, test := range tests {
+		ok, errs := test.options.validate()
+		if ok != test.valid {
+			t.Errorf("test %d: expect %t, but got %t: %v", i, test.valid, ok, errs)
+		}
+	}
+}
+
+func TestKubeletOverridesIncludeMaxPods(t *testing.T) {
+	o := KubeletOptions{Overrides: map[string]string{"v": "4"}, MaxPods: 250}
+	expected := map[string]string{"v": "4", "max-pods": "250"}
+	if got := o.kubeletOverrides(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, but got %v", expected, got)
+	}
+	if _, found := o.Overrides["max-pods"]; found {
+		t.Errorf("the overrides of the plan were modified")
+	}
+	o = KubeletOptions{Overrides: map[string]string{"v": "4"}}
+	if got := o.kubeletOverrides(); !reflect.DeepEqual(got, o.Overrides) {
+		t.Errorf("expected %v, but got %v", o.Overrides, got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/apprenda/kismatic/pkg/tls"
@@ -45,7 +46,7 @@ type PKI interface {
 	GenerateClusterCertificates(p *Plan, clusterCA *tls.CA, proxyClientCA *tls.CA) error
 	NodeCertificateExists(node Node) (bool, error)
 	GenerateNodeCertificate(plan *Plan, node Node, ca *tls.CA) error
-	GenerateCertificate(name string, validityPeriod string, commonName string, subjectAlternateNames []string, organizations []string, ca *tls.CA, overwrite bool) (bool, error)
+	GenerateCertificate(name string, validityPeriod string, commonName string, subjectAlternateNames []string, organizations []string, ca *tls.CA, certs CertsConfig, overwrite bool) (bool, error)
 }
 
 // LocalPKI is a file-based PKI
@@ -125,7 +126,7 @@ func (lp *LocalPKI) GenerateClusterCA(p *Plan) (*tls.CA, error) {
 
 	// CA keypair doesn't exist, generate one
 	util.PrettyPrintOk(lp.Log, "Generating cluster Certificate Authority")
-	key, cert, err := tls.NewCACert(lp.CACsr, p.Cluster.Name, p.Cluster.Certificates.CAExpiry)
+	key, cert, err := tls.NewCACertWithKey(lp.CACsr, p.Cluster.Name, p.Cluster.Certificates.CAExpiry, p.Cluster.Certificates.caKeyRequest())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CA Cert: %v", err)
 	}
@@ -162,7 +163,7 @@ func (lp *LocalPKI) GenerateProxyClientCA(p *Plan) (*tls.CA, error) {
 
 	// CA keypair doesn't exist, generate one
 	util.PrettyPrintOk(lp.Log, "Generating proxy-client Certificate Authority")
-	key, cert, err := tls.NewCACert(lp.CACsr, proxyClientCACommonName, p.Cluster.Certificates.CAExpiry)
+	key, cert, err := tls.NewCACertWithKey(lp.CACsr, proxyClientCACommonName, p.Cluster.Certificates.CAExpiry, p.Cluster.Certificates.caKeyRequest())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create proxy-client CA Cert: %v", err)
 	}
@@ -235,7 +236,7 @@ func (lp *LocalPKI) GenerateClusterCertificates(p *Plan, clusterCA *tls.CA, prox
 		}
 
 		// Cert doesn't exist. Generate it
-		if err := generateCert(lp.GeneratedCertsDirectory, s, p.Cluster.Certificates.Expiry); err != nil {
+		if err := generateCert(lp.GeneratedCertsDirectory, s, p.Cluster.Certificates.Expiry, p.Cluster.Certificates.keyRequest()); err != nil {
 			return err
 		}
 		util.PrettyPrintOk(lp.Log, "Generated certificate for %s", s.description)
@@ -277,6 +278,9 @@ func (lp *LocalPKI) ValidateClusterCertificates(p *Plan) (warns []error, errs []
 	if err != nil {
 		return nil, []error{err}
 	}
+	if err := lp.validateDNSDomainUnchanged(p); err != nil {
+		errs = append(errs, err)
+	}
 	for _, s := range manifest {
 		exists, err := tls.CertKeyPairExists(s.filename, lp.GeneratedCertsDirectory)
 		if err != nil {
@@ -297,6 +301,31 @@ func (lp *LocalPKI) ValidateClusterCertificates(p *Plan) (warns []error, errs []
 	return warns, errs
 }
 
+// the DNS domain is part of the names that the API server certificates were
+// issued for, so an existing certificate for a different domain means that
+// the domain of an installed cluster is being changed
+func (lp *LocalPKI) validateDNSDomainUnchanged(p *Plan) error {
+	domain := p.Cluster.Networking.ClusterDNSDomain()
+	prefix := "kubernetes.default.svc."
+	for _, n := range p.Master.Nodes {
+		name := fmt.Sprintf("%s-apiserver", n.Host)
+		exists, err := tls.CertKeyPairExists(name, lp.GeneratedCertsDirectory)
+		if err != nil || !exists {
+			continue
+		}
+		cert, err := tls.ReadCert(name, lp.GeneratedCertsDirectory)
+		if err != nil {
+			continue // reported when validating the certificate
+		}
+		for _, dnsName := range cert.DNSNames {
+			if strings.HasPrefix(dnsName, prefix) && dnsName != prefix+domain {
+				return fmt.Errorf("The cluster DNS domain cannot be changed from %q to %q once the cluster is installed", strings.TrimPrefix(dnsName, prefix), domain)
+			}
+		}
+	}
+	return nil
+}
+
 // NodeCertificateExists returns true if the node's key and certificate exist
 func (lp *LocalPKI) NodeCertificateExists(node Node) (bool, error) {
 	return tls.CertKeyPairExists(node.Host, lp.GeneratedCertsDirectory)
@@ -328,7 +357,7 @@ func (lp *LocalPKI) GenerateNodeCertificate(plan *Plan, node Node, ca *tls.CA) e
 			continue
 		}
 		// Cert doesn't exist. Generate it
-		if err := generateCert(lp.GeneratedCertsDirectory, s, plan.Cluster.Certificates.Expiry); err != nil {
+		if err := generateCert(lp.GeneratedCertsDirectory, s, plan.Cluster.Certificates.Expiry, plan.Cluster.Certificates.keyRequest()); err != nil {
 			return err
 		}
 		util.PrettyPrintOk(lp.Log, "Generated certificate for %s", s.description)
@@ -337,9 +366,10 @@ func (lp *LocalPKI) GenerateNodeCertificate(plan *Plan, node Node, ca *tls.CA) e
 }
 
 // GenerateCertificate creates a private key and certificate for the given name, CN, subjectAlternateNames and organizations
+// The private key is generated according to the key algorithm and size of the certificates config
 // If cert exists, will not fail
 // Pass overwrite to replace an existing cert
-func (lp *LocalPKI) GenerateCertificate(name string, validityPeriod string, commonName string, subjectAlternateNames []string, organizations []string, ca *tls.CA, overwrite bool) (bool, error) {
+func (lp *LocalPKI) GenerateCertificate(name string, validityPeriod string, commonName string, subjectAlternateNames []string, organizations []string, ca *tls.CA, certs CertsConfig, overwrite bool) (bool, error) {
 	if name == "" {
 		return false, fmt.Errorf("name cannot be empty")
 	}
@@ -368,24 +398,21 @@ func (lp *LocalPKI) GenerateCertificate(name string, validityPeriod string, comm
 		ca:                    ca,
 	}
 
-	if err := generateCert(lp.GeneratedCertsDirectory, spec, validityPeriod); err != nil {
+	if err := generateCert(lp.GeneratedCertsDirectory, spec, validityPeriod, certs.keyRequest()); err != nil {
 		return exists, fmt.Errorf("could not generate certificate %s: %v", name, err)
 	}
 
 	return exists, nil
 }
 
-func generateCert(certDir string, spec certificateSpec, expiryStr string) error {
+func generateCert(certDir string, spec certificateSpec, expiryStr string, keyRequest *csr.BasicKeyRequest) error {
 	expiry, err := time.ParseDuration(expiryStr)
 	if err != nil {
 		return fmt.Errorf("%q is not a valid duration for certificate expiry", expiryStr)
 	}
 	req := csr.CertificateRequest{
-		CN: spec.commonName,
-		KeyRequest: &csr.BasicKeyRequest{
-			A: "rsa",
-			S: 2048,
-		},
+		CN:         spec.commonName,
+		KeyRequest: keyRequest,
 	}
 
 	if len(spec.subjectAlternateNames) > 0 {
@@ -407,6 +434,37 @@ func generateCert(certDir string, spec certificateSpec, expiryStr string) error
 	return nil
 }
 
+var defaultKeySizes = map[string]int{
+	"rsa":   2048,
+	"ecdsa": 256,
+}
+
+func certKeyAlgorithms() []string {
+	return []string{"rsa", "ecdsa"}
+}
+
+// keyRequest returns the private key parameters for the generated certificates
+func (c CertsConfig) keyRequest() *csr.BasicKeyRequest {
+	algo := c.KeyAlgorithm
+	if algo == "" {
+		algo = "rsa"
+	}
+	size := c.KeySize
+	if size == 0 {
+		size = defaultKeySizes[algo]
+	}
+	return &csr.BasicKeyRequest{A: algo, S: size}
+}
+
+// caKeyRequest returns the private key parameters for the generated CAs,
+// or nil if the parameters in the CA's CSR file should be used
+func (c CertsConfig) caKeyRequest() *csr.BasicKeyRequest {
+	if c.KeyAlgorithm == "" && c.KeySize == 0 {
+		return nil
+	}
+	return c.keyRequest()
+}
+
 func clusterCertsSubjectAlternateNames(plan Plan) ([]string, error) {
 	kubeServiceIP, err := getKubernetesServiceIP(&plan)
 	if err != nil {
@@ -416,13 +474,18 @@ func clusterCertsSubjectAlternateNames(plan Plan) ([]string, error) {
 		"kubernetes",
 		"kubernetes.default",
 		"kubernetes.default.svc",
-		"kubernetes.default.svc.cluster.local",
+		kubernetesServiceDNSName(plan),
 		"127.0.0.1",
 		kubeServiceIP,
 	}
 	return defaultCertHosts, nil
 }
 
+// the fully qualified name of the kubernetes service
+func kubernetesServiceDNSName(plan Plan) string {
+	return "kubernetes.default.svc." + plan.Cluster.Networking.ClusterDNSDomain()
+}
+
 func contains(x string, xs []string) bool {
 	for _, s := range xs {
 		if x == s {
@@ -728,6 +728,35 @@ func TestAPIServerCertContainsInternalIP(t *testing.T) {
 	}
 }
 
+func TestAPIServerCertSpecContainsExtraSANs(t *testing.T) {
+	p := getPlan()
+	p.Cluster.Certificates.APIServerCertExtraSANs = "api.example.com, 10.1.2.3,"
+	node := p.Master.Nodes[0]
+	specs, err := node.certSpecs(*p, nil)
+	if err != nil {
+		t.Fatalf("unexpected error getting certificate specs: %v", err)
+	}
+	var apiServerSpec *certificateSpec
+	for i := range specs {
+		if specs[i].filename == fmt.Sprintf("%s-apiserver", node.Host) {
+			apiServerSpec = &specs[i]
+		}
+	}
+	if apiServerSpec == nil {
+		t.Fatalf("did not find the API server certificate spec in %v", specs)
+	}
+	for _, san := range []string{"api.example.com", "10.1.2.3"} {
+		if !contains(san, apiServerSpec.subjectAlternateNames) {
+			t.Errorf("expected SAN %q in the API server certificate request, but got %v", san, apiServerSpec.subjectAlternateNames)
+		}
+	}
+	for _, san := range apiServerSpec.subjectAlternateNames {
+		if san == "" {
+			t.Errorf("found an empty SAN in the API server certificate request")
+		}
+	}
+}
+
 func TestValidateClusterCertificatesNoExistingCerts(t *testing.T) {
 	pki := getPKI(t)
 	defer cleanup(pki.GeneratedCertsDirectory, t)
@@ -768,6 +797,37 @@ func TestValidateClusterCertificatesWithValidExistingCerts(t *testing.T) {
 	}
 }
 
+func TestValidateClusterCertificatesChangedDNSDomain(t *testing.T) {
+	pki := getPKI(t)
+	defer cleanup(pki.GeneratedCertsDirectory, t)
+
+	p := getPlan()
+
+	ca, err := pki.GenerateClusterCA(p)
+	if err != nil {
+		t.Fatalf("error generating CA for test: %v", err)
+	}
+	proxyClientCA, err := pki.GenerateProxyClientCA(p)
+	if err != nil {
+		t.Fatalf("error generating proxy-client CA for test: %v", err)
+	}
+	if err = pki.GenerateClusterCertificates(p, ca, proxyClientCA); err != nil {
+		t.Fatalf("failed to generate certs: %v", err)
+	}
+
+	p.Cluster.Networking.DNSDomain = "east.example.com"
+	_, errs := pki.ValidateClusterCertificates(p)
+	found := false
+	for _, err := range errs {
+		if strings.Contains(err.Error(), "DNS domain cannot be changed") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an error about changing the DNS domain, but got: %v", errs)
+	}
+}
+
 func TestValidateClusterCertificatesInvalidCerts(t *testing.T) {
 	pki := getPKI(t)
 	defer cleanup(pki.GeneratedCertsDirectory, t)
@@ -827,6 +887,30 @@ func TestValidateClusterCertificatesInvalidCerts(t *testing.T) {
 	}
 }
 
+func TestCertsConfigKeyRequest(t *testing.T) {
+	tests := []struct {
+		config    CertsConfig
+		algorithm string
+		size      int
+		caDefault bool
+	}{
+		{config: CertsConfig{}, algorithm: "rsa", size: 2048, caDefault: true},
+		{config: CertsConfig{KeySize: 4096}, algorithm: "rsa", size: 4096},
+		{config: CertsConfig{KeyAlgorithm: "rsa"}, algorithm: "rsa", size: 2048},
+		{config: CertsConfig{KeyAlgorithm: "ecdsa"}, algorithm: "ecdsa", size: 256},
+		{config: CertsConfig{KeyAlgorithm: "ecdsa", KeySize: 521}, algorithm: "ecdsa", size: 521},
+	}
+	for _, test := range tests {
+		kr := test.config.keyRequest()
+		if kr.A != test.algorithm || kr.S != test.size {
+			t.Errorf("%+v: expected %s %d key, but got %s %d", test.config, test.algorithm, test.size, kr.A, kr.S)
+		}
+		if caKR := test.config.caKeyRequest(); (caKR == nil) != test.caDefault {
+			t.Errorf("%+v: expected CA key request to be nil: %t, but got %v", test.config, test.caDefault, caKR)
+		}
+	}
+}
+
 func TestCertSpecEqual(t *testing.T) {
 	tests := []struct {
 		x     certificateSpec
@@ -1105,7 +1189,7 @@ func TestGenerateCertificate(t *testing.T) {
 		},
 	}
 	for i, test := range tests {
-		exists, err := pki.GenerateCertificate(test.name, test.validityPeriod, test.commonName, test.subjectAlternateNames, test.organizations, test.ca, test.overwrite)
+		exists, err := pki.GenerateCertificate(test.name, test.validityPeriod, test.commonName, test.subjectAlternateNames, test.organizations, test.ca, CertsConfig{}, test.overwrite)
 
 		if (err != nil) == test.valid {
 			t.Errorf("test %d: expect valid to be %t, but got %v", i, test.valid, err)
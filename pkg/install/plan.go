@@ -19,6 +19,10 @@ import (
 const (
 	ket133PackageManagerProvider = "helm"
 	defaultCAExpiry              = "17520h"
+	defaultDNSReplicas           = 2
+	defaultNodeCIDRMaskSize      = 24
+	defaultKubeletMaxPods        = 110
+	defaultDNSDomain             = "cluster.local"
 )
 
 // PlanTemplateOptions contains the options that are desired when generating
@@ -26,6 +30,7 @@ const (
 type PlanTemplateOptions struct {
 	EtcdNodes       int
 	MasterNodes     int
+	StackedEtcd     bool
 	WorkerNodes     int
 	IngressNodes    int
 	StorageNodes    int
@@ -186,7 +191,7 @@ func setDefaults(p *Plan) {
 		p.AddOns.DNS.Provider = "kubedns"
 	}
 	if p.AddOns.DNS.Options.Replicas <= 0 {
-		p.AddOns.DNS.Options.Replicas = 2
+		p.AddOns.DNS.Options.Replicas = defaultDNSReplicas
 	}
 
 	if p.AddOns.HeapsterMonitoring == nil {
@@ -362,6 +367,7 @@ func buildPlanFromTemplateOptions(templateOpts PlanTemplateOptions) Plan {
 	p.Cluster.AdminPassword = templateOpts.AdminPassword
 	p.Cluster.DisablePackageInstallation = false
 	p.Cluster.DisconnectedInstallation = false
+	p.Cluster.StackedEtcd = templateOpts.StackedEtcd
 
 	// Set SSH defaults
 	p.Cluster.SSH.User = "kismaticuser"
@@ -401,7 +407,7 @@ func buildPlanFromTemplateOptions(templateOpts PlanTemplateOptions) Plan {
 	p.AddOns.CNI.Options.Calico.IPAutodetectionMethod = "first-found"
 	// DNS
 	p.AddOns.DNS.Provider = "kubedns"
-	p.AddOns.DNS.Options.Replicas = 2
+	p.AddOns.DNS.Options.Replicas = defaultDNSReplicas
 	// Heapster
 	p.AddOns.HeapsterMonitoring = &HeapsterMonitoring{}
 	p.AddOns.HeapsterMonitoring.Options.Heapster.Replicas = 2
@@ -480,6 +486,7 @@ var commentMap = map[string][]string{
 	"cluster.version":                                    []string{fmt.Sprintf("Kubernetes cluster version (supported minor version %q).", kubernetesMinorVersionString)},
 	"cluster.disable_package_installation":               []string{"Set to true if the nodes have the required packages installed."},
 	"cluster.disconnected_installation":                  []string{"Set to true if you are performing a disconnected installation."},
+	"cluster.stacked_etcd":                               []string{"Set to true to run etcd on the master nodes. The etcd and master node groups", "must then list the same nodes."},
 	"cluster.networking":                                 []string{"Networking configuration of your cluster."},
 	"cluster.networking.pod_cidr_block":                  []string{"Kubernetes will assign pods IPs in this range. Do not use a range that is", "already in use on your local network!"},
 	"cluster.networking.service_cidr_block":              []string{"Kubernetes will assign services IPs in this range. Do not use a range", "that is already in use by your local network or pod network!"},
@@ -106,6 +106,11 @@ type Cluster struct {
 	// This field will be removed completely in a future release.
 	// +deprecated
 	AdminPassword string `yaml:"admin_password,omitempty"`
+	// Whether etcd runs on the master nodes.
+	// When true, the etcd node group must list the same nodes as the master node group.
+	// When false, the groups are independent and may still share nodes.
+	// +default=false
+	StackedEtcd bool `yaml:"stacked_etcd,omitempty"`
 	// Whether KET should install the packages on the cluster nodes.
 	// When true, KET will not install the required packages.
 	// Instead, it will verify that the packages have been installed by the operator.
@@ -137,15 +142,86 @@ type Cluster struct {
 	KubeletOptions KubeletOptions `yaml:"kubelet"`
 	// The CloudProvider configuration for the cluster.
 	CloudProvider CloudProvider `yaml:"cloud_provider"`
+	// Maintenance configuration of the etcd cluster used by Kubernetes.
+	EtcdOptions EtcdOptions `yaml:"etcd,omitempty"`
+	// How long the installation waits for each component to become ready.
+	Timeouts ComponentTimeouts `yaml:"timeouts,omitempty"`
+	// Whether new worker nodes register with the `node.kismatic.io/not-ready:NoSchedule`
+	// taint, which is removed once the node's pod network is ready. Prevents workloads
+	// from being scheduled on nodes that cannot run them yet.
+	// Requires the calico or weave CNI provider.
+	// +default=false
+	WorkerStartupTaint bool `yaml:"worker_startup_taint,omitempty"`
+	// Configuration of the ingress controller that runs on the ingress nodes.
+	Ingress IngressOptions `yaml:"ingress,omitempty"`
+	// Default compute resource requests, limits and quotas that are applied
+	// to namespaces after the cluster is installed.
+	ResourceDefaults []NamespaceResourceDefaults `yaml:"resource_defaults,omitempty"`
 }
 
 type APIServerOptions struct {
+	// OpenID Connect authentication of users against an external identity provider.
+	OIDC OIDCOptions `yaml:"oidc,omitempty"`
+	// Admission plugins to enable or disable in addition to the defaults.
+	AdmissionPlugins AdmissionPlugins `yaml:"admission_plugins,omitempty"`
+	// The TLS versions and cipher suites accepted by the API server.
+	TLS TLSOptions `yaml:"tls,omitempty"`
 	// Listing of option overrides that are to be applied to the Kubernetes
 	// API server configuration. This is an advanced feature that can prevent
 	// the API server from starting up if invalid configuration is provided.
 	Overrides map[string]string `yaml:"option_overrides"`
 }
 
+// OIDCOptions configure the API server to authenticate users with the ID tokens
+// issued by an OpenID Connect provider. The API server only verifies the tokens,
+// so the provider's client secret is not part of the cluster configuration.
+type OIDCOptions struct {
+	// URL of the provider that issues the ID tokens, such as `https://accounts.example.com`.
+	// Must use the https scheme. When set, OIDC authentication is enabled.
+	IssuerURL string `yaml:"issuer_url,omitempty"`
+	// The client ID that all ID tokens must be issued for.
+	// Required when the issuer URL is set.
+	ClientID string `yaml:"client_id,omitempty"`
+	// The ID token claim used as the user name.
+	// +default=sub
+	UsernameClaim string `yaml:"username_claim,omitempty"`
+	// The ID token claim used for the user's groups.
+	// When empty, groups are not read from the ID tokens.
+	GroupsClaim string `yaml:"groups_claim,omitempty"`
+	// Path on the local machine to the CA certificate that signed the provider's
+	// serving certificate. The file is copied to the master nodes.
+	// When empty, the host's root CAs are used.
+	CAFile string `yaml:"ca_file,omitempty"`
+}
+
+// AdmissionPlugins adjust the admission plugins of the API server. The default
+// plugins are NamespaceLifecycle, LimitRanger, ServiceAccount, NodeRestriction,
+// PersistentVolumeLabel, DefaultStorageClass, DefaultTolerationSeconds,
+// MutatingAdmissionWebhook, ValidatingAdmissionWebhook and ResourceQuota.
+type AdmissionPlugins struct {
+	// Admission plugins to enable in addition to the defaults, such as `AlwaysPullImages`.
+	Enable []string `yaml:"enable,omitempty"`
+	// Admission plugins to disable, including plugins that are enabled by default.
+	Disable []string `yaml:"disable,omitempty"`
+	// Whether the NodeRestriction plugin can be disabled. Without it, the kubelets
+	// can modify any node and pod objects in the cluster.
+	// +default=false
+	AllowDisablingNodeRestriction bool `yaml:"allow_disabling_node_restriction,omitempty"`
+}
+
+// TLSOptions restrict the TLS connections that a component accepts, such as
+// for compliance with a security policy
+type TLSOptions struct {
+	// The minimum TLS version that clients must use.
+	// When empty, the Kubernetes default is used.
+	// +options=VersionTLS10,VersionTLS11,VersionTLS12
+	MinVersion string `yaml:"min_version,omitempty"`
+	// The cipher suites that clients can use, such as `TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256`.
+	// Must include TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 or TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	// as they are required by HTTP/2. When empty, the Go default cipher suites are used.
+	CipherSuites []string `yaml:"cipher_suites,omitempty"`
+}
+
 type KubeControllerManagerOptions struct {
 	// Listing of option overrides that are to be applied to the Kubernetes
 	// Controller Manager configuration. This is an advanced feature that can prevent
@@ -154,12 +230,63 @@ type KubeControllerManagerOptions struct {
 }
 
 type KubeProxyOptions struct {
+	// The mode used by kube-proxy to implement Services.
+	// IPVS mode requires the ip_vs kernel modules to be loaded on the nodes.
+	// +options=iptables,ipvs
+	// +default=iptables
+	Mode string `yaml:"mode,omitempty"`
 	// Listing of option overrides that are to be applied to the Kubernetes
 	// Proxy configuration. This is an advanced feature that can prevent
 	// the Proxy from starting up if invalid configuration is provided.
 	Overrides map[string]string `yaml:"option_overrides"`
 }
 
+type EtcdOptions struct {
+	// The number of hours of history that etcd keeps before compacting the
+	// keyspace. When 0, etcd does not compact the keyspace on its own, and
+	// relies on the API server, which compacts it every 5 minutes.
+	// +default=0
+	AutoCompactionRetentionHours int `yaml:"auto_compaction_retention_hours,omitempty"`
+	// The schedule on which the etcd members are defragmented to reclaim
+	// the space freed by compaction, in systemd calendar event format.
+	// The members are defragmented one at a time.
+	// When empty, etcd is not defragmented.
+	// Example: "Sun *-*-* 03:00:00"
+	DefragSchedule string `yaml:"defrag_schedule,omitempty"`
+}
+
+// IngressOptions configure the ingress controller
+type IngressOptions struct {
+	// Path on the local machine to the PEM-encoded certificate that the ingress
+	// controller serves for requests that do not match the TLS configuration of
+	// an Ingress, such as a wildcard certificate for the cluster's main domain.
+	// When empty, the ingress controller serves a self-signed certificate.
+	DefaultCertificateFile string `yaml:"default_certificate_file,omitempty"`
+	// Path on the local machine to the PEM-encoded private key of the default certificate.
+	// Required when the default certificate is set.
+	// The key is stored in a Kubernetes secret, which is not encrypted in etcd.
+	DefaultKeyFile string `yaml:"default_key_file,omitempty"`
+}
+
+// ComponentTimeouts are the times that the installation waits for the
+// components to become ready, as durations. Raise them for environments
+// where components are slow to start, such as etcd on slow disks.
+type ComponentTimeouts struct {
+	// How long to wait for the etcd members to be running and healthy.
+	// +default=15s
+	Etcd string `yaml:"etcd,omitempty"`
+	// How long to wait for the control plane pods of each master node.
+	// +default=5m
+	ControlPlane string `yaml:"control_plane,omitempty"`
+	// How long to wait for the CNI pods of each node.
+	// +default=2m
+	CNI string `yaml:"cni,omitempty"`
+	// How long to wait in total for the system pods, such as the CNI, kube-proxy,
+	// DNS and metrics server pods, to be ready after the smoke test.
+	// +default=5m
+	SystemPods string `yaml:"system_pods,omitempty"`
+}
+
 type KubeSchedulerOptions struct {
 	// Listing of option overrides that are to be applied to the Kubernetes
 	// Scheduler configuration. This is an advanced feature that can prevent
@@ -171,6 +298,11 @@ type KubeletOptions struct {
 	// Listing of option overrides that are to be applied to the Kubelet configurations.
 	// This is an advanced feature that can prevent the Kubelet from starting up if invalid configuration is provided.
 	Overrides map[string]string `yaml:"option_overrides"`
+	// The maximum number of pods that can run on the node.
+	// When set on a node, it takes precedence over the cluster-wide setting.
+	// Must fit in the node's pod CIDR block.
+	// +default=110
+	MaxPods int `yaml:"max_pods,omitempty"`
 }
 
 // NetworkConfig describes the cluster's networking configuration
@@ -186,6 +318,20 @@ type NetworkConfig struct {
 	// The Kubernetes service network's CIDR block. For example: `172.20.0.0/16`
 	// +required
 	ServiceCIDRBlock string `yaml:"service_cidr_block"`
+	// CIDR blocks that are already routed on the network of the nodes, such as
+	// the node subnets or the corporate network. The pod and service CIDR
+	// blocks cannot overlap them.
+	ReservedCIDRBlocks []string `yaml:"reserved_cidr_blocks,omitempty"`
+	// The size of the pod CIDR block that is allocated to each node, as a
+	// prefix length. The pod CIDR block must be large enough to allocate a
+	// block to every node in the cluster.
+	// +default=24
+	NodeCIDRMaskSize int `yaml:"node_cidr_mask_size,omitempty"`
+	// The DNS domain of the cluster, used for the names of services and pods.
+	// Clusters that resolve each other's names need different domains.
+	// Cannot be changed once the cluster is installed.
+	// +default=cluster.local
+	DNSDomain string `yaml:"dns_domain,omitempty"`
 	// Whether the /etc/hosts file should be updated on the cluster nodes.
 	// When set to true, KET will update the hosts file on all nodes to include
 	// entries for all other nodes in the cluster.
@@ -201,6 +347,14 @@ type NetworkConfig struct {
 	NoProxy string `yaml:"no_proxy"`
 }
 
+// ClusterDNSDomain returns the DNS domain of the cluster
+func (n NetworkConfig) ClusterDNSDomain() string {
+	if n.DNSDomain == "" {
+		return defaultDNSDomain
+	}
+	return n.DNSDomain
+}
+
 // CertsConfig describes the cluster's trust and certificate configuration
 type CertsConfig struct {
 	// The length of time that the generated certificates should be valid for.
@@ -214,6 +368,18 @@ type CertsConfig struct {
 	// Comma-separated list of Subject Alternative Names (SANs) to use for the API Server serving certificate.
 	// Can be both IP addresses and DNS names.
 	APIServerCertExtraSANs string `yaml:"apiserver_cert_extra_sans"`
+	// The algorithm of the private keys that are generated for the
+	// certificates. Only applies to certificates that are generated after
+	// it is set. When left blank, the CA key is generated as described in
+	// the CA's CSR file.
+	// +options=rsa,ecdsa
+	// +default=rsa
+	KeyAlgorithm string `yaml:"key_algorithm,omitempty"`
+	// The size of the private keys that are generated for the certificates,
+	// in bits. RSA keys can be between 2048 and 8192 bits. ECDSA keys can be
+	// 256, 384 or 521 bits.
+	// +default=2048 for RSA keys, 256 for ECDSA keys
+	KeySize int `yaml:"key_size,omitempty"`
 }
 
 // SSHConfig describes the cluster's SSH configuration for accessing nodes
@@ -240,6 +406,24 @@ type CloudProvider struct {
 	Config string
 }
 
+// NamespaceResourceDefaults is the resource governance configuration applied
+// to a namespace. A LimitRange is created for the default requests and limits,
+// and a ResourceQuota is created for the quota.
+type NamespaceResourceDefaults struct {
+	// The namespace to configure. The namespace is created if it does not exist.
+	// +required
+	Namespace string
+	// Default resource requests for containers that do not specify them,
+	// keyed by resource name. For example, `cpu: 100m`.
+	DefaultRequests map[string]string `yaml:"default_requests,omitempty"`
+	// Default resource limits for containers that do not specify them,
+	// keyed by resource name. For example, `memory: 512Mi`.
+	DefaultLimits map[string]string `yaml:"default_limits,omitempty"`
+	// Hard resource quota for the namespace, keyed by resource name.
+	// For example, `requests.cpu: "4"` or `pods: "20"`.
+	Quota map[string]string `yaml:"quota,omitempty"`
+}
+
 // Docker includes the configuration for the docker installation owned by KET.
 type Docker struct {
 	// Set to true to disable the installation of docker container runtime on the nodes.
@@ -250,6 +434,10 @@ type Docker struct {
 	Logs DockerLogs
 	// Storage configuration for the docker engine.
 	Storage DockerStorage
+	// List of registry mirrors that docker will pull images through.
+	// Each mirror must be an http or https URL, such as `https://mirror.example.com`.
+	// The mirrors must be reachable from all nodes.
+	RegistryMirrors []string `yaml:"registry_mirrors,omitempty"`
 }
 
 // DockerLogs includes the log-specific configuration for docker.
@@ -258,6 +446,9 @@ type DockerLogs struct {
 	// +default=json-file
 	Driver string
 	// Driver specific options.
+	// The json-file and local drivers rotate container logs using the `max-size`
+	// (e.g. `50m`) and `max-file` options. Size the rotation so that it fits in the
+	// free space available to docker on every node.
 	Opts map[string]string
 }
 
@@ -463,6 +654,21 @@ type DNSOptions struct {
 	// Number of cluster DNS replicas that should be scheduled on the cluster.
 	// +default=2
 	Replicas int
+	// The number of seconds that CoreDNS caches responses for.
+	// Only supported by the coredns provider.
+	// +default=30
+	CacheTTL int `yaml:"cache_ttl,omitempty"`
+	// The DNS servers that CoreDNS forwards queries for names outside of the
+	// cluster to, as an IP address with an optional port.
+	// Only supported by the coredns provider.
+	// +default=the nameservers in /etc/resolv.conf of the node
+	Upstreams []string `yaml:"upstreams,omitempty"`
+	// Whether CoreDNS should resolve the search path of the client pod on
+	// the server side, which reduces the number of queries that are sent
+	// for names outside of the cluster.
+	// Only supported by the coredns provider.
+	// +default=false
+	Autopath bool `yaml:"autopath,omitempty"`
 }
 
 // The HeapsterMonitoring add-on configuration
@@ -511,6 +717,20 @@ type MetricsServer struct {
 	// When set to true, metrics-server will not be deployed on the cluster.
 	// +default=false
 	Disable bool
+	// The options that can be configured for the metrics-server add-on
+	Options MetricsServerOptions `yaml:"options,omitempty"`
+}
+
+// The MetricsServerOptions for the metrics-server add-on
+type MetricsServerOptions struct {
+	// Whether metrics-server should skip verifying the serving certificates
+	// of the kubelets. Only needed when the kubelets use self-signed
+	// certificates that are not issued by the cluster CA.
+	// +default=false
+	KubeletInsecureTLS bool `yaml:"kubelet_insecure_tls,omitempty"`
+	// The interval at which metrics are scraped from the kubelets, as a duration.
+	// +default=60s
+	MetricResolution string `yaml:"metric_resolution,omitempty"`
 }
 
 // InfluxDB configuration options for the Heapster add-on
@@ -601,6 +821,11 @@ type MasterNodeGroup struct {
 	// In the case where there is only one master node, this can be set to the IP address of the master nodes.
 	// +deprecated
 	LoadBalancedShortName *string `yaml:"load_balanced_short_name,omitempty"`
+	// Whether workloads can be scheduled on the master nodes.
+	// By default, master nodes that are not also worker nodes are marked
+	// unschedulable. Intended for small and development clusters.
+	// +default=false
+	AllowWorkloads bool `yaml:"allow_workloads,omitempty"`
 	// List of master nodes that are part of the cluster.
 	// +required
 	Nodes []Node
@@ -971,8 +1196,8 @@ func (node Node) certSpecs(plan Plan, ca *tls.CA) ([]certificateSpec, error) {
 		// include any additional SANs
 		sans := strings.Split(plan.Cluster.Certificates.APIServerCertExtraSANs, ",")
 		for _, s := range sans {
-			if s != "" {
-				san = append(san, strings.TrimSpace(s))
+			if s = strings.TrimSpace(s); s != "" {
+				san = append(san, s)
 			}
 		}
 		// include LoadBalancer if not already in the list
@@ -0,0 +1,108 @@
+package install
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+)
+
+// removedOption is a component option that is no longer supported starting
+// with a given Kubernetes version
+type removedOption struct {
+	component   string
+	option      string
+	removedIn   semver.Version
+	replacement string
+}
+
+// the component options that were removed from Kubernetes. Add an entry
+// here when a supported Kubernetes version removes an option that users
+// can set through the option overrides.
+var removedOptions = []removedOption{
+	{
+		component:   "kubelet",
+		option:      "api-servers",
+		removedIn:   semver.Version{Major: 1, Minor: 8},
+		replacement: "kubeconfig",
+	},
+	{
+		component: "kubelet",
+		option:    "require-kubeconfig",
+		removedIn: semver.Version{Major: 1, Minor: 10},
+	},
+}
+
+// ValidateUpgradeCompatibility verifies that the settings in the plan are
+// supported by the Kubernetes version that the cluster is being upgraded to
+func ValidateUpgradeCompatibility(p *Plan, cv ClusterVersion) (bool, []error) {
+	v := newValidator()
+	target, err := parseVersion(p.Cluster.Version)
+	if err != nil {
+		v.addError(fmt.Errorf("Invalid cluster version: %v", err))
+		return v.valid()
+	}
+	if current, found := currentKubernetesVersion(cv); found {
+		if target.LT(current) {
+			v.addError(fmt.Errorf("Cluster version %q is older than the installed version %q, downgrades are not supported", p.Cluster.Version, "v"+current.String()))
+		} else if target.Major != current.Major || target.Minor > current.Minor+1 {
+			v.addError(fmt.Errorf("Cluster version %q skips a minor version of the installed version %q, upgrade one minor version at a time", p.Cluster.Version, "v"+current.String()))
+		}
+	}
+	overrides := componentOverrides(p)
+	for _, r := range removedOptions {
+		if target.LT(r.removedIn) {
+			continue
+		}
+		for _, source := range overrides[r.component] {
+			if _, found := source.overrides[r.option]; !found {
+				continue
+			}
+			err := fmt.Errorf("The %s option %q in %s was removed in Kubernetes v%d.%d", r.component, r.option, source.name, r.removedIn.Major, r.removedIn.Minor)
+			if r.replacement != "" {
+				err = fmt.Errorf("%v, use %q instead", err, r.replacement)
+			}
+			v.addError(err)
+		}
+	}
+	return v.valid()
+}
+
+// the oldest Kubernetes version that is running on the nodes
+func currentKubernetesVersion(cv ClusterVersion) (semver.Version, bool) {
+	var versions []semver.Version
+	for _, n := range cv.Nodes {
+		if n.ComponentVersions.Kubernetes == "" {
+			continue
+		}
+		if v, err := parseVersion(n.ComponentVersions.Kubernetes); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	if len(versions) == 0 {
+		return semver.Version{}, false
+	}
+	semver.Sort(versions)
+	return versions[0], true
+}
+
+type optionOverrides struct {
+	name      string
+	overrides map[string]string
+}
+
+// the option overrides in the plan for each component
+func componentOverrides(p *Plan) map[string][]optionOverrides {
+	o := map[string][]optionOverrides{
+		"kube-apiserver":          {{name: "cluster.kube_apiserver", overrides: p.Cluster.APIServerOptions.Overrides}},
+		"kube-controller-manager": {{name: "cluster.kube_controller_manager", overrides: p.Cluster.KubeControllerManagerOptions.Overrides}},
+		"kube-scheduler":          {{name: "cluster.kube_scheduler", overrides: p.Cluster.KubeSchedulerOptions.Overrides}},
+		"kube-proxy":              {{name: "cluster.kube_proxy", overrides: p.Cluster.KubeProxyOptions.Overrides}},
+		"kubelet":                 {{name: "cluster.kubelet", overrides: p.Cluster.KubeletOptions.Overrides}},
+	}
+	for _, n := range p.GetUniqueNodes() {
+		if len(n.KubeletOptions.Overrides) > 0 {
+			o["kubelet"] = append(o["kubelet"], optionOverrides{name: fmt.Sprintf("the kubelet options of node %q", n.Host), overrides: n.KubeletOptions.Overrides})
+		}
+	}
+	return o
+}
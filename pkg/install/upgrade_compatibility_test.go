@@ -0,0 +1,80 @@
+package install
+
+import "testing"
+
+func TestValidateUpgradeCompatibility(t *testing.T) {
+	tests := []struct {
+		name             string
+		target           string
+		installed        []string
+		clusterOverrides map[string]string
+		nodeOverrides    map[string]string
+		valid            bool
+	}{
+		{
+			name:      "patch upgrade",
+			target:    "v1.10.5",
+			installed: []string{"v1.10.3", "v1.10.3"},
+			valid:     true,
+		},
+		{
+			name:      "minor upgrade",
+			target:    "v1.10.5",
+			installed: []string{"v1.9.6", "v1.10.5"},
+			valid:     true,
+		},
+		{
+			name:      "unknown installed version",
+			target:    "v1.10.5",
+			installed: []string{""},
+			valid:     true,
+		},
+		{
+			name:      "skipped minor version",
+			target:    "v1.10.5",
+			installed: []string{"v1.10.5", "v1.8.4"},
+			valid:     false,
+		},
+		{
+			name:      "downgrade",
+			target:    "v1.10.3",
+			installed: []string{"v1.10.5"},
+			valid:     false,
+		},
+		{
+			name:             "removed kubelet option",
+			target:           "v1.10.5",
+			installed:        []string{"v1.9.6"},
+			clusterOverrides: map[string]string{"require-kubeconfig": "true"},
+			valid:            false,
+		},
+		{
+			name:          "removed kubelet option on node",
+			target:        "v1.10.5",
+			installed:     []string{"v1.9.6"},
+			nodeOverrides: map[string]string{"require-kubeconfig": "true"},
+			valid:         false,
+		},
+		{
+			name:             "supported kubelet option",
+			target:           "v1.10.5",
+			installed:        []string{"v1.9.6"},
+			clusterOverrides: map[string]string{"max-pods": "200"},
+			valid:            true,
+		},
+	}
+	for _, test := range tests {
+		p := validPlan()
+		p.Cluster.Version = test.target
+		p.Cluster.KubeletOptions.Overrides = test.clusterOverrides
+		p.Worker.Nodes[0].KubeletOptions.Overrides = test.nodeOverrides
+		cv := ClusterVersion{}
+		for _, v := range test.installed {
+			cv.Nodes = append(cv.Nodes, ListableNode{ComponentVersions: ComponentVersions{Kubernetes: v}})
+		}
+		ok, errs := ValidateUpgradeCompatibility(&p, cv)
+		if ok != test.valid {
+			t.Errorf("%s: expected %t, but got %t: %v", test.name, test.valid, ok, errs)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package install
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -145,6 +147,22 @@ func (p *Plan) validate() (bool, []error) {
 
 	v.validateWithErrPrefix("Docker", p.Docker)
 	v.validate(&additionalFilesGroup{AdditionalFiles: p.AdditionalFiles, Plan: p})
+	v.validate(&podCIDRAllocation{Networking: p.Cluster.Networking, Plan: p})
+	v.validate(&networkOverlap{Plan: p})
+	v.validate(&dnsReplicas{DNS: p.AddOns.DNS, Plan: p})
+	v.validate(&maxPodsAllocation{Plan: p})
+	v.validate(&featureGatesCompatibility{Plan: p})
+	if p.Cluster.Ingress.DefaultCertificateFile != "" && len(p.Ingress.Nodes) == 0 {
+		v.addError(errors.New("An ingress default certificate requires at least one ingress node"))
+	}
+	if p.Cluster.WorkerStartupTaint {
+		if p.AddOns.CNI == nil || p.AddOns.CNI.Disable || (p.AddOns.CNI.Provider != cniProviderCalico && p.AddOns.CNI.Provider != cniProviderWeave) {
+			v.addError(errors.New("The worker startup taint requires the calico or weave CNI provider, as their pods tolerate the taint"))
+		}
+	}
+	if p.Cluster.StackedEtcd {
+		v.addError(validateStackedEtcd(p)...)
+	}
 	v.validate(&p.AddOns)
 	v.validate(nodeList{Nodes: p.getAllNodes()})
 	v.validateWithErrPrefix("Etcd nodes", &p.Etcd)
@@ -192,6 +210,19 @@ func (c *Cluster) validate() (bool, []error) {
 	v.validate(&c.KubeSchedulerOptions)
 	v.validate(&c.KubeletOptions)
 	v.validate(&c.CloudProvider)
+	v.validate(&c.EtcdOptions)
+	v.validate(&c.Timeouts)
+	v.validateWithErrPrefix("Ingress", &c.Ingress)
+
+	namespaces := map[string]bool{}
+	for i := range c.ResourceDefaults {
+		rd := &c.ResourceDefaults[i]
+		if namespaces[rd.Namespace] {
+			v.addError(fmt.Errorf("Resource defaults for namespace %q are defined more than once", rd.Namespace))
+		}
+		namespaces[rd.Namespace] = true
+		v.validate(rd)
+	}
 
 	return v.valid()
 }
@@ -211,6 +242,19 @@ func (n *NetworkConfig) validate() (bool, []error) {
 	if _, _, err := net.ParseCIDR(n.ServiceCIDRBlock); n.ServiceCIDRBlock != "" && err != nil {
 		v.addError(fmt.Errorf("Invalid Service CIDR block provided: %v", err))
 	}
+	for _, r := range n.ReservedCIDRBlocks {
+		if _, _, err := net.ParseCIDR(r); err != nil {
+			v.addError(fmt.Errorf("Invalid reserved CIDR block provided: %v", err))
+		}
+	}
+	if n.NodeCIDRMaskSize < 0 {
+		v.addError(fmt.Errorf("Node CIDR mask size %d is not valid, must be greater than 0", n.NodeCIDRMaskSize))
+	}
+	if n.DNSDomain != "" {
+		for _, err := range validation.IsDNS1123Subdomain(n.DNSDomain) {
+			v.addError(fmt.Errorf("Cluster DNS domain %q is not a valid DNS domain: %s", n.DNSDomain, err))
+		}
+	}
 	return v.valid()
 }
 
@@ -222,6 +266,32 @@ func (c *CertsConfig) validate() (bool, []error) {
 	if _, err := time.ParseDuration(c.CAExpiry); c.CAExpiry != "" && err != nil { // don't error when empty for backwards compat
 		v.addError(fmt.Errorf("Invalid CA certificate expiry %q provider: %v", c.CAExpiry, err))
 	}
+	for _, san := range strings.Split(c.APIServerCertExtraSANs, ",") {
+		san = strings.TrimSpace(san)
+		if san == "" || net.ParseIP(san) != nil {
+			continue
+		}
+		// allow wildcard names, such as *.example.com. DNS names are case
+		// insensitive and some hosts use underscores, so only reject names
+		// that are not valid with those normalized.
+		name := strings.TrimPrefix(san, "*.")
+		name = strings.Replace(strings.ToLower(name), "_", "-", -1)
+		for _, err := range validation.IsDNS1123Subdomain(name) {
+			v.addError(fmt.Errorf("API server certificate extra SAN %q is not a valid IP address or DNS name: %s", san, err))
+		}
+	}
+	switch c.KeyAlgorithm {
+	case "", "rsa":
+		if c.KeySize != 0 && (c.KeySize < 2048 || c.KeySize > 8192) {
+			v.addError(fmt.Errorf("Invalid certificate key size %d: RSA keys must be between 2048 and 8192 bits", c.KeySize))
+		}
+	case "ecdsa":
+		if c.KeySize != 0 && c.KeySize != 256 && c.KeySize != 384 && c.KeySize != 521 {
+			v.addError(fmt.Errorf("Invalid certificate key size %d: ECDSA keys must be 256, 384 or 521 bits", c.KeySize))
+		}
+	default:
+		v.addError(fmt.Errorf("Invalid certificate key algorithm %q. Options are %v", c.KeyAlgorithm, certKeyAlgorithms()))
+	}
 	return v.valid()
 }
 
@@ -260,6 +330,247 @@ func (c *CloudProvider) validate() (bool, []error) {
 	return v.valid()
 }
 
+func (rd *NamespaceResourceDefaults) validate() (bool, []error) {
+	v := newValidator()
+	if rd.Namespace == "" {
+		v.addError(errors.New("Resource defaults namespace cannot be empty"))
+	} else {
+		for _, err := range validation.IsDNS1123Label(rd.Namespace) {
+			v.addError(fmt.Errorf("Resource defaults namespace %q is not valid %s", rd.Namespace, err))
+		}
+	}
+	if len(rd.DefaultRequests) == 0 && len(rd.DefaultLimits) == 0 && len(rd.Quota) == 0 {
+		v.addError(fmt.Errorf("Resource defaults for namespace %q must include default requests, default limits or a quota", rd.Namespace))
+	}
+	// a quota of zero forbids the resource in the namespace
+	validateQuantities := func(field string, resources map[string]string, isValid func(string) []string) {
+		for name, qty := range resources {
+			for _, err := range validation.IsQualifiedName(name) {
+				v.addError(fmt.Errorf("Resource defaults %s name %q is not valid %s", field, name, err))
+			}
+			for _, err := range isValid(qty) {
+				v.addError(fmt.Errorf("Resource defaults %s %q quantity %q is not valid: %s", field, name, qty, err))
+			}
+		}
+	}
+	validateQuantities("default request", rd.DefaultRequests, validation.IsPositiveQuantity)
+	validateQuantities("default limit", rd.DefaultLimits, validation.IsPositiveQuantity)
+	validateQuantities("quota", rd.Quota, validation.IsNonNegativeQuantity)
+	return v.valid()
+}
+
+type podCIDRAllocation struct {
+	Networking NetworkConfig
+	Plan       *Plan
+}
+
+// verify that the pod CIDR block can be split into a block for every node
+// that runs the kubelet
+func (a *podCIDRAllocation) validate() (bool, []error) {
+	v := newValidator()
+	if a.Networking.NodeCIDRMaskSize == 0 {
+		return v.valid()
+	}
+	if _, found := a.Plan.Cluster.KubeControllerManagerOptions.Overrides["node-cidr-mask-size"]; found {
+		v.addError(fmt.Errorf("Node CIDR mask size cannot be set together with the %q option override", "node-cidr-mask-size"))
+	}
+	_, podNet, err := net.ParseCIDR(a.Networking.PodCIDRBlock)
+	if err != nil {
+		// the pod CIDR block is validated with the rest of the network config
+		return v.valid()
+	}
+	prefix, bits := podNet.Mask.Size()
+	mask := a.Networking.NodeCIDRMaskSize
+	if mask < prefix || mask > bits {
+		v.addError(fmt.Errorf("Node CIDR mask size %d is not valid for pod CIDR block %q, must be between %d and %d", mask, a.Networking.PodCIDRBlock, prefix, bits))
+		return v.valid()
+	}
+	nodes := len(kubeletNodes(a.Plan))
+	if mask-prefix < 32 && nodes > 1<<uint(mask-prefix) {
+		v.addError(fmt.Errorf("Pod CIDR block %q only has room for %d node CIDR blocks of size /%d, but the cluster has %d nodes", a.Networking.PodCIDRBlock, 1<<uint(mask-prefix), mask, nodes))
+	}
+	return v.valid()
+}
+
+type networkOverlap struct {
+	Plan *Plan
+}
+
+type clusterNetwork struct {
+	name  string
+	block string
+	net   *net.IPNet
+}
+
+// verify that the pod and service networks do not overlap each other, the
+// reserved CIDR blocks or the addresses of the nodes, as the overlapping
+// traffic would not be routed to its destination
+func (o *networkOverlap) validate() (bool, []error) {
+	v := newValidator()
+	n := o.Plan.Cluster.Networking
+	_, podNet, podErr := net.ParseCIDR(n.PodCIDRBlock)
+	_, serviceNet, serviceErr := net.ParseCIDR(n.ServiceCIDRBlock)
+	// the CIDR blocks are validated with the rest of the network config
+	if podErr == nil && serviceErr == nil && cidrsOverlap(podNet, serviceNet) {
+		v.addError(fmt.Errorf("Pod CIDR block %q overlaps the service CIDR block %q", n.PodCIDRBlock, n.ServiceCIDRBlock))
+	}
+	clusterNets := []clusterNetwork{}
+	if podErr == nil {
+		clusterNets = append(clusterNets, clusterNetwork{name: "Pod", block: n.PodCIDRBlock, net: podNet})
+	}
+	if serviceErr == nil {
+		clusterNets = append(clusterNets, clusterNetwork{name: "Service", block: n.ServiceCIDRBlock, net: serviceNet})
+	}
+	for _, c := range clusterNets {
+		for _, r := range n.ReservedCIDRBlocks {
+			_, reservedNet, err := net.ParseCIDR(r)
+			if err != nil {
+				continue
+			}
+			if cidrsOverlap(c.net, reservedNet) {
+				v.addError(fmt.Errorf("%s CIDR block %q overlaps the reserved CIDR block %q", c.name, c.block, r))
+			}
+		}
+		for _, node := range o.Plan.GetUniqueNodes() {
+			for _, addr := range []string{node.IP, node.InternalIP} {
+				if ip := net.ParseIP(addr); ip != nil && c.net.Contains(ip) {
+					v.addError(fmt.Errorf("%s CIDR block %q contains the address %q of node %q", c.name, c.block, addr, node.Host))
+				}
+			}
+		}
+	}
+	return v.valid()
+}
+
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func (i *IngressOptions) validate() (bool, []error) {
+	v := newValidator()
+	if i.DefaultCertificateFile == "" && i.DefaultKeyFile == "" {
+		return v.valid()
+	}
+	if i.DefaultCertificateFile == "" || i.DefaultKeyFile == "" {
+		v.addError(errors.New("The default certificate and key files must be set together"))
+		return v.valid()
+	}
+	if _, err := tls.LoadX509KeyPair(i.DefaultCertificateFile, i.DefaultKeyFile); err != nil {
+		v.addError(fmt.Errorf("The default certificate %q and key %q are not a valid pair: %v", i.DefaultCertificateFile, i.DefaultKeyFile, err))
+	}
+	return v.valid()
+}
+
+// when etcd is stacked, the etcd and master node groups must list the same nodes
+func validateStackedEtcd(p *Plan) []error {
+	errs := []error{}
+	if p.Etcd.ExpectedCount != p.Master.ExpectedCount {
+		errs = append(errs, fmt.Errorf("Etcd expected count %d must equal the master expected count %d when stacked_etcd is true", p.Etcd.ExpectedCount, p.Master.ExpectedCount))
+	}
+	masters := map[string]bool{}
+	for _, n := range p.Master.Nodes {
+		masters[n.HashCode()] = true
+	}
+	etcds := map[string]bool{}
+	for _, n := range p.Etcd.Nodes {
+		etcds[n.HashCode()] = true
+		if !masters[n.HashCode()] {
+			errs = append(errs, fmt.Errorf("Etcd node %q must also be a master node when stacked_etcd is true", n.Host))
+		}
+	}
+	for _, n := range p.Master.Nodes {
+		if !etcds[n.HashCode()] {
+			errs = append(errs, fmt.Errorf("Master node %q must also be an etcd node when stacked_etcd is true", n.Host))
+		}
+	}
+	return errs
+}
+
+type maxPodsAllocation struct {
+	Plan *Plan
+}
+
+// verify that the node CIDR blocks have enough pod IPs for the maximum
+// number of pods of every node
+func (a *maxPodsAllocation) validate() (bool, []error) {
+	v := newValidator()
+	_, podNet, err := net.ParseCIDR(a.Plan.Cluster.Networking.PodCIDRBlock)
+	if err != nil {
+		// the pod CIDR block is validated with the rest of the network config
+		return v.valid()
+	}
+	_, bits := podNet.Mask.Size()
+	mask := nodeCIDRMaskSize(a.Plan)
+	if mask <= 0 || mask > bits || bits-mask >= 31 {
+		return v.valid()
+	}
+	// the network and broadcast addresses cannot be assigned to pods
+	podIPs := 1<<uint(bits-mask) - 2
+	for _, n := range kubeletNodes(a.Plan) {
+		maxPods, ok := kubeletMaxPods(n.KubeletOptions, a.Plan.Cluster.KubeletOptions)
+		if !ok {
+			// the kubelet rejects an invalid max-pods option override
+			continue
+		}
+		if maxPods < 0 {
+			v.addError(fmt.Errorf("Kubelet max pods %d of node %q is not valid, must be greater than 0", maxPods, n.Host))
+			continue
+		}
+		if maxPods > podIPs {
+			v.addError(fmt.Errorf("Kubelet max pods %d of node %q is greater than the %d pod IPs in a /%d node CIDR block", maxPods, n.Host, podIPs, mask))
+		}
+	}
+	return v.valid()
+}
+
+// the maximum number of pods of the kubelet, using the first of the options
+// that sets it, either with the max pods field or the "max-pods" override.
+// False is returned if the override is not a number.
+func kubeletMaxPods(options ...KubeletOptions) (int, bool) {
+	for _, o := range options {
+		if o.MaxPods != 0 {
+			return o.MaxPods, true
+		}
+		if override, found := o.Overrides["max-pods"]; found {
+			maxPods, err := strconv.Atoi(override)
+			return maxPods, err == nil
+		}
+	}
+	return defaultKubeletMaxPods, true
+}
+
+// the size of the pod CIDR block that is allocated to each node
+func nodeCIDRMaskSize(p *Plan) int {
+	if p.Cluster.Networking.NodeCIDRMaskSize > 0 {
+		return p.Cluster.Networking.NodeCIDRMaskSize
+	}
+	if override, found := p.Cluster.KubeControllerManagerOptions.Overrides["node-cidr-mask-size"]; found {
+		mask, err := strconv.Atoi(override)
+		if err != nil {
+			return 0
+		}
+		return mask
+	}
+	return defaultNodeCIDRMaskSize
+}
+
+// the unique nodes that run the kubelet, and are allocated a pod CIDR block
+func kubeletNodes(p *Plan) []Node {
+	seen := map[string]bool{}
+	nodes := []Node{}
+	groups := [][]Node{p.Master.Nodes, p.Worker.Nodes, p.Ingress.Nodes, p.Storage.Nodes}
+	for _, g := range groups {
+		for _, n := range g {
+			if seen[n.HashCode()] {
+				continue
+			}
+			seen[n.HashCode()] = true
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
 type additionalFilesGroup struct {
 	AdditionalFiles []AdditionalFile
 	Plan            *Plan
@@ -296,6 +607,7 @@ func (f *AddOns) validate() (bool, []error) {
 	v.validate(f.CNI)
 	v.validate(f.DNS)
 	v.validate(f.HeapsterMonitoring)
+	v.validate(&f.MetricsServer)
 	v.validate(&f.Dashboard)
 	v.validate(&f.PackageManager)
 	return v.valid()
@@ -325,10 +637,65 @@ func (n DNS) validate() (bool, []error) {
 		if !util.Contains(n.Provider, dnsProviders()) {
 			v.addError(fmt.Errorf("%q is not a valid DNS provider. Optins are %v", n.Provider, dnsProviders()))
 		}
+		if n.Options.CacheTTL < 0 {
+			v.addError(fmt.Errorf("DNS cache TTL %d is not valid, must be greater than 0", n.Options.CacheTTL))
+		}
+		for _, u := range n.Options.Upstreams {
+			host := u
+			if h, _, err := net.SplitHostPort(u); err == nil {
+				host = h
+			}
+			if net.ParseIP(host) == nil {
+				v.addError(fmt.Errorf("DNS upstream %q is not a valid IP address with an optional port", u))
+			}
+		}
+		if n.Provider != "coredns" && (n.Options.CacheTTL != 0 || len(n.Options.Upstreams) > 0 || n.Options.Autopath) {
+			v.addError(fmt.Errorf("DNS cache TTL, upstreams and autopath are only supported by the coredns provider"))
+		}
 	}
 	return v.valid()
 }
 
+type dnsReplicas struct {
+	DNS  DNS
+	Plan *Plan
+}
+
+// verify that the DNS replicas can be spread across the schedulable nodes.
+// The default replica count is always allowed, as the replicas only prefer
+// to run on different nodes.
+func (d *dnsReplicas) validate() (bool, []error) {
+	v := newValidator()
+	if d.DNS.Disable || d.DNS.Options.Replicas <= defaultDNSReplicas {
+		return v.valid()
+	}
+	nodes := len(schedulableNodes(d.Plan))
+	if d.DNS.Options.Replicas > nodes {
+		v.addError(fmt.Errorf("DNS replicas %d is greater than the %d schedulable nodes in the cluster", d.DNS.Options.Replicas, nodes))
+	}
+	return v.valid()
+}
+
+// the unique nodes that workloads can be scheduled on
+func schedulableNodes(p *Plan) []Node {
+	seen := map[string]bool{}
+	nodes := []Node{}
+	groups := [][]Node{p.Worker.Nodes}
+	if p.Master.AllowWorkloads {
+		groups = append(groups, p.Master.Nodes)
+	}
+	for _, g := range groups {
+		for _, n := range g {
+			if seen[n.HashCode()] {
+				continue
+			}
+			seen[n.HashCode()] = true
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
 func (h *HeapsterMonitoring) validate() (bool, []error) {
 	v := newValidator()
 	if h != nil && !h.Disable {
@@ -342,6 +709,25 @@ func (h *HeapsterMonitoring) validate() (bool, []error) {
 	return v.valid()
 }
 
+func (m *MetricsServer) validate() (bool, []error) {
+	v := newValidator()
+	if m.Disable {
+		if m.Options.KubeletInsecureTLS || m.Options.MetricResolution != "" {
+			v.addError(fmt.Errorf("Metrics server options cannot be set when the metrics server is disabled"))
+		}
+		return v.valid()
+	}
+	if m.Options.MetricResolution != "" {
+		d, err := time.ParseDuration(m.Options.MetricResolution)
+		if err != nil {
+			v.addError(fmt.Errorf("Invalid metrics server metric resolution %q provided: %v", m.Options.MetricResolution, err))
+		} else if d < time.Second {
+			v.addError(fmt.Errorf("Metrics server metric resolution %q is not valid, must be at least 1s", m.Options.MetricResolution))
+		}
+	}
+	return v.valid()
+}
+
 func (d *Dashboard) validate() (bool, []error) {
 	v := newValidator()
 	if d != nil && !d.Disable {
@@ -420,15 +806,22 @@ func (nl nodeList) validate() (bool, []error) {
 
 func validateNoDuplicateNodeInfo(nodes []Node) []error {
 	errs := []error{}
-	hostnames := map[string]string{}
+	// the kubelet lowercases the hostname when registering the node, so
+	// hostnames that only differ in case collide
+	hostnames := map[string]Node{}
 	ips := map[string]string{}
 	internalIPs := map[string]string{}
 	for _, n := range nodes {
 		// Validate all hostnames are unique
-		if val, ok := hostnames[n.Host]; n.Host != "" && ok && val != n.HashCode() {
-			errs = append(errs, fmt.Errorf("Two different nodes cannot have the same hostname %q", n.Host))
-		} else if n.Host != "" {
-			hostnames[n.Host] = n.HashCode()
+		name := strings.ToLower(n.Host)
+		if val, ok := hostnames[name]; n.Host != "" && ok && val.HashCode() != n.HashCode() {
+			if val.Host == n.Host {
+				errs = append(errs, fmt.Errorf("Two different nodes cannot have the same hostname %q", n.Host))
+			} else {
+				errs = append(errs, fmt.Errorf("Two different nodes cannot have the hostnames %q and %q, as they register with the same node name %q", val.Host, n.Host, name))
+			}
+		} else if n.Host != "" && !ok {
+			hostnames[name] = n
 		}
 		// Validate all IPs are unique
 		if val, ok := ips[n.IP]; n.IP != "" && ok && val != n.HashCode() {
@@ -448,12 +841,12 @@ func validateNoDuplicateNodeInfo(nodes []Node) []error {
 
 func validateKubeletOptionsDefinedOnce(nodes []Node) []error {
 	errs := []error{}
-	seenNodes := map[string]map[string]string{}
+	seenNodes := map[string]KubeletOptions{}
 	for _, n := range nodes {
-		if val, ok := seenNodes[n.HashCode()]; ok && !reflect.DeepEqual(val, n.KubeletOptions.Overrides) {
+		if val, ok := seenNodes[n.HashCode()]; ok && !reflect.DeepEqual(val, n.KubeletOptions) {
 			errs = append(errs, fmt.Errorf("Cannot redefine kubelet options for node %q", n.Host))
 		} else {
-			seenNodes[n.HashCode()] = n.KubeletOptions.Overrides
+			seenNodes[n.HashCode()] = n.KubeletOptions
 		}
 	}
 	return errs
@@ -595,6 +988,82 @@ func (dr *DockerRegistry) validate() (bool, []error) {
 func (d Docker) validate() (bool, []error) {
 	v := newValidator()
 	v.validateWithErrPrefix("Storage", d.Storage)
+	v.validateWithErrPrefix("Logs", d.Logs)
+	if d.Disable && len(d.RegistryMirrors) > 0 {
+		v.addError(errors.New("Registry mirrors cannot be configured when the docker installation is disabled"))
+	}
+	seen := map[string]bool{}
+	for _, m := range d.RegistryMirrors {
+		if err := validateRegistryMirror(m); err != nil {
+			v.addError(err)
+		}
+		key := normalizeRegistryMirror(m)
+		if seen[key] {
+			v.addError(fmt.Errorf("Registry mirror %q is listed more than once", m))
+		}
+		seen[key] = true
+	}
+	return v.valid()
+}
+
+// normalizeRegistryMirror returns the mirror as it is configured on the nodes,
+// without a trailing slash. The scheme and host are not case sensitive.
+func normalizeRegistryMirror(mirror string) string {
+	mirror = strings.TrimSuffix(mirror, "/")
+	u, err := url.Parse(mirror)
+	if err != nil || u.Host == "" {
+		return mirror
+	}
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host)
+}
+
+// docker only accepts a scheme, host and optional port for mirrors
+func validateRegistryMirror(mirror string) error {
+	u, err := url.Parse(mirror)
+	if err != nil {
+		return fmt.Errorf("Registry mirror %q is not a valid URL: %v", mirror, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("Registry mirror %q must use the http or https scheme", mirror)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("Registry mirror %q must include a host", mirror)
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return fmt.Errorf("Registry mirror %q cannot include a path, query, fragment or credentials", mirror)
+	}
+	return nil
+}
+
+// sizes accepted by docker for the max-size log option, e.g. "50m" or "1g"
+var dockerLogSizeRE = regexp.MustCompile(`^[1-9]\d*(\.\d+)? ?[kKmMgG]?[bB]?$`)
+
+// log rotation is only understood by the drivers that write logs to the local disk
+var rotatingLogDrivers = map[string]bool{
+	"json-file": true,
+	"local":     true,
+}
+
+func (dl DockerLogs) validate() (bool, []error) {
+	v := newValidator()
+	maxSize, hasMaxSize := dl.Opts["max-size"]
+	maxFile, hasMaxFile := dl.Opts["max-file"]
+	if (hasMaxSize || hasMaxFile) && !rotatingLogDrivers[dl.Driver] {
+		v.addError(fmt.Errorf("Log rotation options max-size and max-file are not supported by the %q logging driver", dl.Driver))
+		return v.valid()
+	}
+	if hasMaxSize && maxSize != "-1" && !dockerLogSizeRE.MatchString(maxSize) {
+		v.addError(fmt.Errorf("Log option max-size %q is not a valid size, such as 50m or 1g", maxSize))
+	}
+	if hasMaxFile {
+		n, err := strconv.Atoi(maxFile)
+		if err != nil || n < 1 {
+			v.addError(fmt.Errorf("Log option max-file %q must be a positive number", maxFile))
+		}
+		if n > 1 && (!hasMaxSize || maxSize == "-1") {
+			v.addError(errors.New("Log option max-file cannot be greater than 1 unless max-size is also set"))
+		}
+	}
 	return v.valid()
 }
 
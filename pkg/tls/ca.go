@@ -27,6 +27,13 @@ type Subject struct {
 
 // NewCACert creates a new Certificate Authority and returns it's private key and public certificate.
 func NewCACert(csrFile string, commonName string, expiry string) (key, cert []byte, err error) {
+	return NewCACertWithKey(csrFile, commonName, expiry, nil)
+}
+
+// NewCACertWithKey creates a new Certificate Authority whose private key is
+// generated according to the key request. The key described in the CSR file
+// is used when the key request is nil.
+func NewCACertWithKey(csrFile string, commonName string, expiry string, keyRequest *csr.BasicKeyRequest) (key, cert []byte, err error) {
 	// Open CSR file
 	f, err := os.Open(csrFile)
 	if os.IsNotExist(err) {
@@ -44,6 +51,9 @@ func NewCACert(csrFile string, commonName string, expiry string) (key, cert []by
 		return nil, nil, fmt.Errorf("error decoding CSR: %v", err)
 	}
 	caCSR.CN = commonName
+	if keyRequest != nil {
+		caCSR.KeyRequest = keyRequest
+	}
 	caCSR.CA = &csr.CAConfig{Expiry: expiry}
 	// Generate CA Cert according to CSR
 	cert, _, key, err = initca.New(caCSR)
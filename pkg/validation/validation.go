@@ -123,6 +123,47 @@ func IsDNS1123Subdomain(value string) []string {
 	return errs
 }
 
+const quantityFmt string = "[+-]?([0-9]+(\\.[0-9]*)?|\\.[0-9]+)(Ki|Mi|Gi|Ti|Pi|Ei|n|u|m|k|M|G|T|P|E|[eE][+-]?[0-9]+)?"
+const quantityErrMsg string = "a quantity must be a number with an optional binary (Ki, Mi, Gi...), decimal (m, k, M, G...) or exponent suffix"
+
+var quantityRegexp = regexp.MustCompile("^" + quantityFmt + "$")
+
+// IsValidQuantity tests for a string that conforms to the format of a
+// Kubernetes resource quantity, such as "100m" or "512Mi".
+func IsValidQuantity(value string) []string {
+	var errs []string
+	if !quantityRegexp.MatchString(value) {
+		errs = append(errs, RegexError(quantityErrMsg, quantityFmt, "100m", "512Mi", "2"))
+	}
+	return errs
+}
+
+// IsPositiveQuantity tests for a Kubernetes resource quantity that is greater
+// than zero.
+func IsPositiveQuantity(value string) []string {
+	m := quantityRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return IsValidQuantity(value)
+	}
+	if strings.HasPrefix(value, "-") || strings.Trim(m[1], "0.") == "" {
+		return []string{"must be greater than zero"}
+	}
+	return nil
+}
+
+// IsNonNegativeQuantity tests for a Kubernetes resource quantity that is
+// zero or greater.
+func IsNonNegativeQuantity(value string) []string {
+	m := quantityRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return IsValidQuantity(value)
+	}
+	if strings.HasPrefix(value, "-") && strings.Trim(m[1], "0.") != "" {
+		return []string{"cannot be negative"}
+	}
+	return nil
+}
+
 // MaxLenError returns a string explanation of a "string too long" validation
 // failure.
 func MaxLenError(length int) string {